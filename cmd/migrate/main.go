@@ -0,0 +1,63 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"Blog-API/internal/infrastructure/database"
+	"Blog-API/internal/infrastructure/markdown"
+	"Blog-API/internal/repository"
+	"Blog-API/internal/usecase"
+	"Blog-API/pkg/config"
+)
+
+// Usage: go run ./cmd/migrate <migration>
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatal("Usage: migrate <migration>\n\nAvailable migrations:\n  comments   move embedded blog comments into the comments collection\n  render     render blogs saved without content_html, excerpt and reading_time\n  reactions  move blog likes and dislikes into the reactions collection")
+	}
+
+	cfg := config.Load()
+
+	mongoDB, err := database.NewMongoDB(cfg.MongoDB.URI, cfg.MongoDB.Database)
+	if err != nil {
+		log.Fatal("Failed to connect to MongoDB:", err)
+	}
+	defer mongoDB.Close()
+
+	switch os.Args[1] {
+	case "comments":
+		moved, err := repository.MigrateEmbeddedComments(mongoDB)
+		if err != nil {
+			log.Fatalf("Comment migration failed after moving %d comments: %v", moved, err)
+		}
+		log.Printf("Moved %d embedded comments into the comments collection", moved)
+	case "render":
+		// rendering only needs the blogs, the users to resolve mentions and the outbox so
+		// that the server's search subscriber re-syncs the rendered blogs
+		blogRepo := repository.NewBlogRepository(mongoDB, repository.TextSearchWeights{
+			Title: cfg.Search.TextTitleWeight,
+			Tags:  cfg.Search.TextTagWeight,
+		})
+		blogUseCase := usecase.NewBlogUseCase(usecase.BlogUseCaseDeps{
+			BlogRepo: blogRepo,
+			UserRepo: repository.NewUserRepository(mongoDB),
+			Renderer: markdown.NewMarkdownRenderer(),
+			Tx:       repository.NewTransactor(mongoDB),
+			Outbox:   repository.NewOutboxRepository(mongoDB),
+		})
+		rendered, err := blogUseCase.BackfillRendered()
+		if err != nil {
+			log.Fatalf("Render migration failed after rendering %d blogs: %v", rendered, err)
+		}
+		log.Printf("Rendered %d blogs saved before content was rendered on write", rendered)
+	case "reactions":
+		moved, err := repository.MigrateEmbeddedReactions(mongoDB)
+		if err != nil {
+			log.Fatalf("Reaction migration failed after moving %d reactions: %v", moved, err)
+		}
+		log.Printf("Moved %d embedded likes and dislikes into the reactions collection", moved)
+	default:
+		log.Fatalf("Unknown migration: %s", os.Args[1])
+	}
+}
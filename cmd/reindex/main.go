@@ -0,0 +1,61 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"Blog-API/internal/infrastructure/database"
+	"Blog-API/internal/infrastructure/search"
+	"Blog-API/internal/repository"
+	"Blog-API/internal/usecase"
+	"Blog-API/pkg/config"
+)
+
+// rebuilds the search index from the database, e.g. after changing SEARCH_LANGUAGE or
+// when the index has drifted. The new index is built beside the current one and swapped
+// in at the end; restart the server afterwards so that it opens the new index.
+func main() {
+	cfg := config.Load()
+
+	mongoDB, err := database.NewMongoDB(cfg.MongoDB.URI, cfg.MongoDB.Database)
+	if err != nil {
+		log.Fatal("Failed to connect to MongoDB:", err)
+	}
+	defer mongoDB.Close()
+
+	building := cfg.Search.IndexPath + ".reindex"
+	if err := os.RemoveAll(building); err != nil {
+		log.Fatal("Failed to clear previous reindex:", err)
+	}
+	searchIndex, err := search.NewBleveIndex(building, search.Options{
+		Language:     cfg.Search.Language,
+		TitleBoost:   cfg.Search.TitleBoost,
+		TagBoost:     cfg.Search.TagBoost,
+		MaxFuzziness: cfg.Search.MaxFuzziness,
+	})
+	if err != nil {
+		log.Fatal("Failed to create search index:", err)
+	}
+
+	// reindexing only reads blogs, so no tag canonicalizer is needed
+	blogRepo := repository.NewBlogRepository(mongoDB, repository.TextSearchWeights{
+		Title: cfg.Search.TextTitleWeight,
+		Tags:  cfg.Search.TextTagWeight,
+	})
+	searchUseCase := usecase.NewSearchUseCase(searchIndex, blogRepo, nil)
+	indexed, err := searchUseCase.Reindex()
+	if closeErr := searchIndex.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		log.Fatal("Failed to reindex:", err)
+	}
+
+	if err := os.RemoveAll(cfg.Search.IndexPath); err != nil {
+		log.Fatal("Failed to remove old search index:", err)
+	}
+	if err := os.Rename(building, cfg.Search.IndexPath); err != nil {
+		log.Fatal("Failed to swap in new search index:", err)
+	}
+	log.Printf("Indexed %d blogs into %s", indexed, cfg.Search.IndexPath)
+}
@@ -3,13 +3,22 @@ package main
 import (
 	"log"
 	"net/http"
+	"time"
 
 	"Blog-API/internal/delivery/controllers"
 	"Blog-API/internal/delivery/router"
+	"Blog-API/internal/domain"
 	"Blog-API/internal/infrastructure/database"
+	"Blog-API/internal/infrastructure/eventbus"
 	"Blog-API/internal/infrastructure/jwt"
+	"Blog-API/internal/infrastructure/markdown"
 	"Blog-API/internal/infrastructure/middleware"
 	"Blog-API/internal/infrastructure/password"
+	"Blog-API/internal/infrastructure/pubsub"
+	"Blog-API/internal/infrastructure/search"
+	"Blog-API/internal/infrastructure/spam"
+	"Blog-API/internal/infrastructure/webhook"
+	"Blog-API/internal/infrastructure/worker"
 	"Blog-API/internal/repository"
 	"Blog-API/internal/usecase"
 	"Blog-API/pkg/config"
@@ -30,20 +39,166 @@ func main() {
 
 	passwordService := password.NewPasswordService()
 	jwtService := jwt.NewJWTService(cfg.JWT.Secret, cfg.JWT.AccessExpiry, cfg.JWT.RefreshExpiry)
+	markdownRenderer := markdown.NewMarkdownRenderer()
+	eventHub := pubsub.NewHub(cfg.Stream.BufferSize, cfg.Stream.HistorySize)
+	webhookSender := webhook.NewSender(cfg.Webhook.Timeout, cfg.Webhook.AllowPrivate)
+	eventBus := eventbus.NewBus()
+	transactor := repository.NewTransactor(mongoDB)
+
+	searchIndex, err := search.NewBleveIndex(cfg.Search.IndexPath, search.Options{
+		Language:     cfg.Search.Language,
+		TitleBoost:   cfg.Search.TitleBoost,
+		TagBoost:     cfg.Search.TagBoost,
+		MaxFuzziness: cfg.Search.MaxFuzziness,
+	})
+	if err != nil {
+		log.Fatal("Failed to open search index:", err)
+	}
+	defer searchIndex.Close()
 
 	userRepo := repository.NewUserRepository(mongoDB)
-	blogRepo := repository.NewBlogRepository(mongoDB)
+	blogRepo := repository.NewBlogRepository(mongoDB, repository.TextSearchWeights{
+		Title: cfg.Search.TextTitleWeight,
+		Tags:  cfg.Search.TextTagWeight,
+	})
 	sessionRepo := repository.NewSessionRepository(mongoDB)
+	seriesRepo := repository.NewSeriesRepository(mongoDB)
+	commentRepo := repository.NewCommentRepository(mongoDB)
+	settingsRepo := repository.NewSettingsRepository(mongoDB)
+	spamModelRepo := repository.NewSpamModelRepository(mongoDB)
+	notificationRepo := repository.NewNotificationRepository(mongoDB)
+	webhookRepo := repository.NewWebhookRepository(mongoDB)
+	webhookDeliveryRepo := repository.NewWebhookDeliveryRepository(mongoDB)
+	outboxRepo := repository.NewOutboxRepository(mongoDB)
+	reactionRepo := repository.NewReactionRepository(mongoDB)
+	bookmarkRepo := repository.NewBookmarkRepository(mongoDB)
+	readingListRepo := repository.NewReadingListRepository(mongoDB)
+	tagRepo := repository.NewTagRepository(mongoDB)
+	tagSynonymRepo := repository.NewTagSynonymRepository(mongoDB)
+	categoryRepo := repository.NewCategoryRepository(mongoDB)
+	trendingRepo := repository.NewTrendingRepository(mongoDB)
+
+	reactionSpecs := cfg.Reactions.Types
+	if len(reactionSpecs) == 0 {
+		reactionSpecs = domain.DefaultReactionTypes
+	}
+	reactionTypes, err := domain.ParseReactionTypes(reactionSpecs)
+	if err != nil {
+		log.Fatal("Invalid REACTION_TYPES:", err)
+	}
+
+	var spamClassifier domain.SpamClassifier
+	if cfg.Spam.Enabled {
+		spamClassifier = spam.NewClassifier(spamModelRepo, cfg.Spam.BlockedDomains)
+	}
+
+	userUseCase := usecase.NewUserUseCase(userRepo, passwordService, jwtService, sessionRepo, transactor, outboxRepo)
+	webhookUseCase := usecase.NewWebhookUseCase(webhookRepo, webhookDeliveryRepo, webhookSender,
+		cfg.Webhook.MaxAttempts, cfg.Webhook.BaseBackoff, cfg.Webhook.DisableAfter)
+	notificationUseCase := usecase.NewNotificationUseCase(notificationRepo, userRepo, eventHub)
+	commentPolicy := usecase.NewCommentPolicy(settingsRepo, commentRepo, cfg.Moderation.CommentMode, spamClassifier, cfg.Spam.Threshold)
+	tagUseCase := usecase.NewTagUseCase(tagRepo, tagSynonymRepo, blogRepo, transactor, outboxRepo)
+	categoryUseCase := usecase.NewCategoryUseCase(categoryRepo, blogRepo, transactor, outboxRepo)
+	trendingUseCase := usecase.NewTrendingUseCase(trendingRepo, blogRepo, domain.TrendingWeights{
+		Views:    cfg.Trending.ViewWeight,
+		Likes:    cfg.Trending.LikeWeight,
+		Dislikes: cfg.Trending.DislikeWeight,
+		Comments: cfg.Trending.CommentWeight,
+		HalfLife: cfg.Trending.HalfLife,
+	}, cfg.Trending.Size)
+	blogUseCase := usecase.NewBlogUseCase(usecase.BlogUseCaseDeps{
+		BlogRepo:      blogRepo,
+		UserRepo:      userRepo,
+		SeriesRepo:    seriesRepo,
+		CommentRepo:   commentRepo,
+		ReactionRepo:  reactionRepo,
+		BookmarkRepo:  bookmarkRepo,
+		TagRepo:       tagRepo,
+		Tags:          tagUseCase,
+		Categories:    categoryUseCase,
+		Trending:      trendingUseCase,
+		Renderer:      markdownRenderer,
+		Policy:        commentPolicy,
+		ReactionTypes: reactionTypes,
+		Notifier:      notificationUseCase,
+		Publisher:     eventHub,
+		Tx:            transactor,
+		Outbox:        outboxRepo,
+	})
+	bookmarkUseCase := usecase.NewBookmarkUseCase(bookmarkRepo, readingListRepo, blogRepo, userRepo)
+	moderationUseCase := usecase.NewModerationUseCase(commentRepo, blogRepo, settingsRepo, commentPolicy, spamClassifier, notificationUseCase, eventHub, transactor, outboxRepo)
+	searchUseCase := usecase.NewSearchUseCase(searchIndex, blogRepo, tagUseCase)
+
+	// subscribers of the domain events recorded in the outbox
+	usecase.SubscribeWebhooks(eventBus, webhookUseCase)
+	usecase.SubscribeSearchIndex(eventBus, searchUseCase)
+
+	// a new search index starts out empty, fill it from the database in the background
+	if count, err := searchIndex.Count(); err == nil && count == 0 {
+		go func() {
+			indexed, err := searchUseCase.Reindex()
+			if err != nil {
+				log.Printf("Failed to build search index: %v", err)
+				return
+			}
+			log.Printf("Indexed %d blogs for search", indexed)
+		}()
+	}
+
+	// permanently remove blogs that have been in the trash past the retention period
+	retention := time.Duration(cfg.Trash.RetentionDays) * 24 * time.Hour
+	stopTrashPurge := worker.Every("trash-purge", cfg.Trash.PurgeInterval, func() error {
+		purged, err := blogUseCase.PurgeTrash(retention)
+		if purged > 0 {
+			log.Printf("Purged %d blogs from the trash", purged)
+		}
+		return err
+	})
+	defer stopTrashPurge()
+
+	// repair tag usage counts that drifted, e.g. from blogs written before tags were tracked
+	if err := tagUseCase.RecountUsage(); err != nil {
+		log.Printf("Failed to recount tag usage: %v", err)
+	}
+	stopTagRecount := worker.Every("tag-recount", cfg.Tags.RecountInterval, tagUseCase.RecountUsage)
+	defer stopTagRecount()
+
+	// rank trending blogs from their current counters; the rankings are served as last computed
+	if err := trendingUseCase.Refresh(); err != nil {
+		log.Printf("Failed to compute trending blogs: %v", err)
+	}
+	stopTrendingRefresh := worker.Every("trending-refresh", cfg.Trending.RefreshInterval, trendingUseCase.Refresh)
+	defer stopTrendingRefresh()
+
+	// hand recorded domain events to their subscribers, retrying the ones that failed
+	outboxDispatcher := eventbus.NewDispatcher(outboxRepo, eventBus, cfg.Outbox.MaxAttempts, cfg.Outbox.BaseBackoff)
+	stopOutbox := worker.Every("outbox-dispatch", cfg.Outbox.PollInterval, func() error {
+		_, err := outboxDispatcher.ProcessPending()
+		return err
+	})
+	defer stopOutbox()
 
-	userUseCase := usecase.NewUserUseCase(userRepo, passwordService, jwtService, sessionRepo)
-	blogUseCase := usecase.NewBlogUseCase(blogRepo, userRepo)
+	// send queued webhook deliveries and retry failed ones once their backoff has passed
+	stopWebhookDeliveries := worker.Every("webhook-deliveries", cfg.Webhook.PollInterval, func() error {
+		_, err := webhookUseCase.ProcessDueDeliveries()
+		return err
+	})
+	defer stopWebhookDeliveries()
 
 	userHandler := controllers.NewUserHandler(userUseCase)
 	blogHandler := controllers.NewBlogHandler(blogUseCase)
+	moderationHandler := controllers.NewModerationHandler(moderationUseCase)
+	notificationHandler := controllers.NewNotificationHandler(notificationUseCase)
+	streamHandler := controllers.NewStreamHandler(eventHub, cfg.Stream.HeartbeatInterval)
+	webhookHandler := controllers.NewWebhookHandler(webhookUseCase)
+	bookmarkHandler := controllers.NewBookmarkHandler(bookmarkUseCase)
+	tagHandler := controllers.NewTagHandler(tagUseCase)
+	categoryHandler := controllers.NewCategoryHandler(categoryUseCase)
+	searchHandler := controllers.NewSearchHandler(searchUseCase)
 
 	authMiddleware := middleware.NewAuthMiddleware(jwtService, sessionRepo)
 
-	router := router.SetupRouter(userHandler, blogHandler, authMiddleware)
+	router := router.SetupRouter(userHandler, blogHandler, moderationHandler, notificationHandler, streamHandler, webhookHandler, bookmarkHandler, tagHandler, categoryHandler, searchHandler, authMiddleware)
 
 	log.Printf("Server starting on port %s", cfg.Server.Port)
 	log.Printf("MongoDB connected to: %s", cfg.MongoDB.URI)
@@ -1,7 +1,9 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -53,16 +55,25 @@ func (h *BlogHandler) CreateBlog(c *gin.Context) {
 		ViewCount:    0,
 		LikeCount:    0,
 		CommentCount: 0,
-		Likes:        []string{},
-		Dislikes:     []string{},
-		Comments:     []domain.Comment{},
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
+	if req.CategoryID != "" {
+		categoryID, err := primitive.ObjectIDFromHex(req.CategoryID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid category ID"})
+			return
+		}
+		blog.CategoryID = &categoryID
+	}
 
 	err := h.blogUseCase.CreateBlog(blog, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: err.Error()})
+		status := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "invalid") {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, domain.ErrorResponse{Error: err.Error()})
 		return
 	}
 
@@ -107,6 +118,17 @@ func (h *BlogHandler) UpdateBlog(c *gin.Context) {
 	if req.Tags != nil {
 		blogUpdate.Tags = *req.Tags
 	}
+	if req.CategoryID != nil {
+		// an empty category_id removes the category
+		categoryID := primitive.NilObjectID
+		if *req.CategoryID != "" {
+			if categoryID, err = primitive.ObjectIDFromHex(*req.CategoryID); err != nil {
+				c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid category ID"})
+				return
+			}
+		}
+		blogUpdate.CategoryID = &categoryID
+	}
 
 	// userRole := domain.RoleUser
 
@@ -117,6 +139,8 @@ func (h *BlogHandler) UpdateBlog(c *gin.Context) {
 			status = http.StatusNotFound
 		} else if strings.Contains(err.Error(), "forbidden") {
 			status = http.StatusForbidden
+		} else if strings.Contains(err.Error(), "invalid") {
+			status = http.StatusBadRequest
 		}
 		c.JSON(status, domain.ErrorResponse{Error: err.Error()})
 		return
@@ -158,10 +182,59 @@ func (h *BlogHandler) DeleteBlog(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Blog deleted successfully",
+		"message": "Blog moved to trash",
 	})
 }
 
+func (h *BlogHandler) RestoreBlog(c *gin.Context) {
+	userID, userExists := middleware.GetUserIDFromContext(c)
+	userRole, roleExists := middleware.GetUserRoleFromContext(c)
+	if !userExists || !roleExists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid blog ID"})
+		return
+	}
+
+	blog, err := h.blogUseCase.RestoreBlog(id, userID, userRole)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "not found") {
+			status = http.StatusNotFound
+		} else if strings.Contains(err.Error(), "forbidden") {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Blog restored successfully",
+		"blog":    blog,
+	})
+}
+
+func (h *BlogHandler) GetTrash(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+	page, limit := getPagination(c)
+
+	blogs, total, err := h.blogUseCase.GetTrash(userID, page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newPaginationResponse(blogs, page, limit, total))
+}
+
 func (h *BlogHandler) SearchBlogsByTitle(c *gin.Context) {
 	title := c.Query("title")
 	if title == "" {
@@ -188,6 +261,9 @@ func (h *BlogHandler) SearchBlogsByTitle(c *gin.Context) {
 		return
 	}
 
+	viewerID, _ := middleware.GetUserIDFromContext(c)
+	h.blogUseCase.MarkBookmarked(viewerID, blogs...)
+
 	totalPages := int((total + int64(limit) - 1) / int64(limit))
 
 	c.JSON(http.StatusOK, domain.PaginationResponse{
@@ -225,6 +301,9 @@ func (h *BlogHandler) SearchBlogsByAuthor(c *gin.Context) {
 		return
 	}
 
+	viewerID, _ := middleware.GetUserIDFromContext(c)
+	h.blogUseCase.MarkBookmarked(viewerID, blogs...)
+
 	totalPages := int((total + int64(limit) - 1) / int64(limit))
 
 	c.JSON(http.StatusOK, domain.PaginationResponse{
@@ -255,61 +334,501 @@ func (h *BlogHandler) GetBlog(c *gin.Context) {
 		return
 	}
 
+	viewerID, _ := middleware.GetUserIDFromContext(c)
+	h.blogUseCase.MarkBookmarked(viewerID, blog)
+
 	c.JSON(http.StatusOK, gin.H{
 		"blog": blog,
 	})
 }
 
-func (h *BlogHandler) GetAllBlogs(c *gin.Context) {
+func (h *BlogHandler) GetBlogBySlug(c *gin.Context) {
+	slug := c.Param("slug")
+
+	blog, err := h.blogUseCase.GetBlogBySlug(slug)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "blog not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	// old slugs permanently redirect to the current one
+	if blog.Slug != slug {
+		c.Redirect(http.StatusMovedPermanently, "/api/v1/blogs/by-slug/"+url.PathEscape(blog.Slug))
+		return
+	}
+
+	viewerID, _ := middleware.GetUserIDFromContext(c)
+	h.blogUseCase.MarkBookmarked(viewerID, blog)
+
 	c.JSON(http.StatusOK, gin.H{
-		"message": "GetAllBlogs endpoint",
+		"blog": blog,
 	})
 }
 
-func (h *BlogHandler) GetPopularBlogs(c *gin.Context) {
+func (h *BlogHandler) GetBlogByPermalink(c *gin.Context) {
+	username := c.Param("username")
+	slug := c.Param("slug")
+
+	blog, err := h.blogUseCase.GetBlogByPermalink(username, slug)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "blog not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if blog.Slug != slug {
+		c.Redirect(http.StatusMovedPermanently, "/@"+url.PathEscape(username)+"/"+url.PathEscape(blog.Slug))
+		return
+	}
+
+	viewerID, _ := middleware.GetUserIDFromContext(c)
+	h.blogUseCase.MarkBookmarked(viewerID, blog)
+
 	c.JSON(http.StatusOK, gin.H{
-		"message": "GetPopularBlogs endpoint",
+		"blog": blog,
 	})
 }
 
-func (h *BlogHandler) FilterBlogsByTags(c *gin.Context) {
+// lists blogs; the filters combine, e.g.
+// ?tags=go,databases&tag_match=all&author=jane&from=2024-01-01&to=2024-06-30&sort=popular;
+// facets=true adds tag, author and month counts over all matches. Passing cursor (empty
+// for the first page) pages with the returned next_cursor and prev_cursor instead of page
+// numbers; count=true then adds the total.
+func (h *BlogHandler) GetAllBlogs(c *gin.Context) {
+	params, err := listBlogParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	viewerID, _ := middleware.GetUserIDFromContext(c)
+	viewerRole, _ := middleware.GetUserRoleFromContext(c)
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		blogs, page, err := h.blogUseCase.ListBlogsByCursor(params, cursor, c.Query("count") == "true", viewerID, viewerRole)
+		if err != nil {
+			c.JSON(listBlogsErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+			return
+		}
+		h.blogUseCase.MarkBookmarked(viewerID, blogs...)
+		c.JSON(http.StatusOK, newCursorPaginationResponse(blogs, params.Limit, page))
+		return
+	}
+
+	blogs, total, facets, err := h.blogUseCase.ListBlogs(params, viewerID, viewerRole)
+	if err != nil {
+		c.JSON(listBlogsErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+	h.blogUseCase.MarkBookmarked(viewerID, blogs...)
+
+	response := newPaginationResponse(blogs, params.Page, params.Limit, total)
+	response.Facets = facets
+	c.JSON(http.StatusOK, response)
+}
+
+// full-text search ranked by relevance, e.g. ?q="go modules" proxy -vendor; takes the
+// same filters and paging options as GetAllBlogs
+func (h *BlogHandler) SearchBlogs(c *gin.Context) {
+	params, err := listBlogParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if strings.TrimSpace(params.Query) == "" {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Query parameter q is required"})
+		return
+	}
+
+	viewerID, _ := middleware.GetUserIDFromContext(c)
+	viewerRole, _ := middleware.GetUserRoleFromContext(c)
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		blogs, page, err := h.blogUseCase.SearchBlogsByCursor(params, cursor, c.Query("count") == "true", viewerID, viewerRole)
+		if err != nil {
+			c.JSON(listBlogsErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+			return
+		}
+		h.blogUseCase.MarkBookmarked(viewerID, blogs...)
+		c.JSON(http.StatusOK, newCursorPaginationResponse(blogs, params.Limit, page))
+		return
+	}
+
+	blogs, total, facets, err := h.blogUseCase.SearchBlogs(params, viewerID, viewerRole)
+	if err != nil {
+		c.JSON(listBlogsErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+	h.blogUseCase.MarkBookmarked(viewerID, blogs...)
+
+	response := newPaginationResponse(blogs, params.Page, params.Limit, total)
+	response.Facets = facets
+	c.JSON(http.StatusOK, response)
+}
+
+// reads the listing filters shared by GetAllBlogs and SearchBlogs from the query string
+func listBlogParams(c *gin.Context) (*domain.ListBlogParams, error) {
+	page, limit := getPagination(c)
+	params := &domain.ListBlogParams{
+		Page:     page,
+		Limit:    limit,
+		Query:    c.Query("q"),
+		Tags:     splitList(c.Query("tags")),
+		TagMatch: c.Query("tag_match"),
+		Author:   strings.TrimSpace(c.Query("author")),
+		Status:   c.Query("status"),
+		SortBy:   c.Query("sort"),
+		Facets:   c.Query("facets") == "true",
+	}
+	var err error
+	if params.From, err = parseDateParam(c.Query("from"), false); err != nil {
+		return nil, errors.New("Invalid from date: " + err.Error())
+	}
+	if params.To, err = parseDateParam(c.Query("to"), true); err != nil {
+		return nil, errors.New("Invalid to date: " + err.Error())
+	}
+	return params, nil
+}
+
+// trending blogs, ranked by engagement that fades with age, e.g. ?window=today&limit=5;
+// the window is today, week (default) or all
+func (h *BlogHandler) GetPopularBlogs(c *gin.Context) {
+	ranking, blogs, err := h.blogUseCase.GetPopularBlogs(c.DefaultQuery("window", domain.TrendingWeek), getLimit(c))
+	if err != nil {
+		c.JSON(listBlogsErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+	viewerID, _ := middleware.GetUserIDFromContext(c)
+	h.blogUseCase.MarkBookmarked(viewerID, blogs...)
+
 	c.JSON(http.StatusOK, gin.H{
-		"message": "FilterBlogsByTags endpoint",
+		"window":      ranking.Window,
+		"computed_at": ranking.ComputedAt,
+		"blogs":       blogs,
 	})
 }
 
+// lists blogs carrying any of the comma separated tags, e.g. ?tags=go,databases
+func (h *BlogHandler) FilterBlogsByTags(c *gin.Context) {
+	tags := splitList(c.Query("tags"))
+	if len(tags) == 0 {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Tags parameter is required"})
+		return
+	}
+	page, limit := getPagination(c)
+
+	blogs, total, err := h.blogUseCase.FilterBlogsByTags(tags, page, limit)
+	if err != nil {
+		c.JSON(listBlogsErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+	viewerID, _ := middleware.GetUserIDFromContext(c)
+	h.blogUseCase.MarkBookmarked(viewerID, blogs...)
+
+	c.JSON(http.StatusOK, newPaginationResponse(blogs, page, limit, total))
+}
+
+// lists blogs created within a date range, e.g. ?start_date=2024-01-01&end_date=2024-01-31
 func (h *BlogHandler) FilterBlogsByDate(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"message": "FilterBlogsByDate endpoint",
-	})
+	startDate, err := parseDateParam(c.Query("start_date"), false)
+	if err != nil || startDate == nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Valid start_date parameter is required"})
+		return
+	}
+	endDate, err := parseDateParam(c.Query("end_date"), true)
+	if err != nil || endDate == nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Valid end_date parameter is required"})
+		return
+	}
+	page, limit := getPagination(c)
+
+	blogs, total, err := h.blogUseCase.FilterBlogsByDate(*startDate, *endDate, page, limit)
+	if err != nil {
+		c.JSON(listBlogsErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+	viewerID, _ := middleware.GetUserIDFromContext(c)
+	h.blogUseCase.MarkBookmarked(viewerID, blogs...)
+
+	c.JSON(http.StatusOK, newPaginationResponse(blogs, page, limit, total))
+}
+
+// maps blog listing errors to HTTP status codes
+func listBlogsErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "forbidden"):
+		return http.StatusForbidden
+	case strings.Contains(err.Error(), "invalid"):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
 }
 
 func (h *BlogHandler) AddComment(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"message": "Addcomment endpoint",
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	blogID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid blog ID"})
+		return
+	}
+
+	var req domain.CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid request data: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return
+	}
+
+	comment := &domain.Comment{
+		AuthorID: userID,
+		Content:  req.Content,
+	}
+	if req.ParentID != "" {
+		parentID, err := primitive.ObjectIDFromHex(req.ParentID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid parent comment ID"})
+			return
+		}
+		comment.ParentID = &parentID
+	}
+
+	if err := h.blogUseCase.AddComment(blogID, comment); err != nil {
+		c.JSON(commentErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	message := "Comment added successfully"
+	if comment.Status == domain.CommentPending {
+		message = "Comment submitted for moderation"
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": message,
+		"comment": comment,
 	})
 }
 
+// lists one level of a thread; like GetAllBlogs it pages by cursor when cursor is passed
+func (h *BlogHandler) GetComments(c *gin.Context) {
+	blogID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid blog ID"})
+		return
+	}
+
+	var parentID *primitive.ObjectID
+	if parent := c.Query("parent_id"); parent != "" {
+		id, err := primitive.ObjectIDFromHex(parent)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid parent comment ID"})
+			return
+		}
+		parentID = &id
+	}
+
+	sort := c.DefaultQuery("sort", domain.CommentSortNewest)
+	if sort != domain.CommentSortNewest && sort != domain.CommentSortOldest && sort != domain.CommentSortTop {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid sort, expected newest, oldest or top"})
+		return
+	}
+	page, limit := getPagination(c)
+
+	// set by OptionalAuth when the caller is logged in
+	viewerID, _ := middleware.GetUserIDFromContext(c)
+	viewerRole, _ := middleware.GetUserRoleFromContext(c)
+
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		comments, cursors, err := h.blogUseCase.GetCommentsByCursor(blogID, parentID, viewerID, viewerRole, sort, cursor, limit, c.Query("count") == "true")
+		if err != nil {
+			c.JSON(commentErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, newCursorPaginationResponse(comments, limit, cursors))
+		return
+	}
+
+	comments, total, err := h.blogUseCase.GetComments(blogID, parentID, viewerID, viewerRole, sort, page, limit)
+	if err != nil {
+		c.JSON(commentErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newPaginationResponse(comments, page, limit, total))
+}
+
 func (h *BlogHandler) DeleteComment(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	blogID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid blog ID"})
+		return
+	}
+	commentID, err := primitive.ObjectIDFromHex(c.Param("commentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid comment ID"})
+		return
+	}
+
+	if err := h.blogUseCase.DeleteComment(blogID, commentID, userID); err != nil {
+		c.JSON(commentErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"message": "DeleteComment endpoint",
+		"message": "Comment deleted successfully",
 	})
 }
 
 func (h *BlogHandler) UpdateComment(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	blogID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid blog ID"})
+		return
+	}
+	commentID, err := primitive.ObjectIDFromHex(c.Param("commentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid comment ID"})
+		return
+	}
+
+	var req domain.UpdateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid request data: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return
+	}
+
+	if err := h.blogUseCase.UpdateComment(blogID, commentID, req.Content, userID); err != nil {
+		c.JSON(commentErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"message": "UpdateComment endpoint",
+		"message": "Comment updated successfully",
 	})
 }
 
-func (h *BlogHandler) LikeBlog(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"message": "LikeBlog endpoint",
-	})
+func commentErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "not found"):
+		return http.StatusNotFound
+	case strings.Contains(err.Error(), "forbidden"):
+		return http.StatusForbidden
+	case strings.Contains(err.Error(), "invalid"):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
 }
 
-func (h *BlogHandler) DislikeBlog(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"message": "DislikeBlog endpoint",
-	})
+// reads page and limit query parameters, falling back to sane defaults
+func getPagination(c *gin.Context) (int, int) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	return page, limit
+}
+
+// page size for listings without page numbers, same bounds as getPagination
+func getLimit(c *gin.Context) int {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 10
+	}
+	return limit
+}
+
+// splits a comma separated query parameter, dropping blank entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parses an RFC 3339 timestamp or a plain date; nil when value is empty. A plain date
+// used as the end of a range covers that whole day.
+func parseDateParam(value string, end bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, errors.New("use YYYY-MM-DD or RFC 3339")
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
+
+func newPaginationResponse(data interface{}, page, limit int, total int64) domain.PaginationResponse {
+	return domain.PaginationResponse{
+		Data:       data,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	}
+}
+
+func newCursorPaginationResponse(data interface{}, limit int, page *domain.CursorPage) domain.CursorPaginationResponse {
+	return domain.CursorPaginationResponse{
+		Data:       data,
+		Limit:      limit,
+		NextCursor: page.Next,
+		PrevCursor: page.Prev,
+		Total:      page.Total,
+	}
+}
+
+func parseObjectIDs(hexIDs []string) ([]primitive.ObjectID, error) {
+	ids := make([]primitive.ObjectID, 0, len(hexIDs))
+	for _, hex := range hexIDs {
+		id, err := primitive.ObjectIDFromHex(hex)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
 }
@@ -0,0 +1,342 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type BookmarkHandler struct {
+	bookmarkUseCase domain.BookmarkUseCase
+	validate        *validator.Validate
+}
+
+func NewBookmarkHandler(bookmarkUseCase domain.BookmarkUseCase) *BookmarkHandler {
+	return &BookmarkHandler{
+		bookmarkUseCase: bookmarkUseCase,
+		validate:        validator.New(),
+	}
+}
+
+func (h *BookmarkHandler) GetBookmarks(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+	limit := getLimit(c)
+
+	bookmarks, page, err := h.bookmarkUseCase.GetBookmarks(userID, c.Query("cursor"), limit, c.Query("count") == "true")
+	if err != nil {
+		c.JSON(bookmarkErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newCursorPaginationResponse(bookmarks, limit, page))
+}
+
+func (h *BookmarkHandler) AddBookmark(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	blogID, ok := h.bindBlogID(c)
+	if !ok {
+		return
+	}
+
+	bookmark, err := h.bookmarkUseCase.AddBookmark(userID, blogID)
+	if err != nil {
+		c.JSON(bookmarkErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "Blog bookmarked successfully",
+		"bookmark": bookmark,
+	})
+}
+
+func (h *BookmarkHandler) RemoveBookmark(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+	blogID, err := primitive.ObjectIDFromHex(c.Param("blogId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid blog ID"})
+		return
+	}
+
+	if err := h.bookmarkUseCase.RemoveBookmark(userID, blogID); err != nil {
+		c.JSON(bookmarkErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Bookmark removed successfully"})
+}
+
+func (h *BookmarkHandler) ReorderBookmarks(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	blogIDs, ok := h.bindOrder(c)
+	if !ok {
+		return
+	}
+
+	if err := h.bookmarkUseCase.ReorderBookmarks(userID, blogIDs); err != nil {
+		c.JSON(bookmarkErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Bookmarks reordered successfully"})
+}
+
+func (h *BookmarkHandler) CreateReadingList(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	var req domain.CreateReadingListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid request data: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return
+	}
+
+	list, err := h.bookmarkUseCase.CreateReadingList(userID, &req)
+	if err != nil {
+		c.JSON(bookmarkErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":      "Reading list created successfully",
+		"reading_list": list,
+	})
+}
+
+// lists a user's public reading lists, or the caller's own lists when no username is given
+func (h *BookmarkHandler) GetReadingLists(c *gin.Context) {
+	viewerID, _ := middleware.GetUserIDFromContext(c)
+	username := c.Query("username")
+	if username == "" && viewerID.IsZero() {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	lists, err := h.bookmarkUseCase.GetReadingLists(username, viewerID)
+	if err != nil {
+		c.JSON(bookmarkErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"reading_lists": lists})
+}
+
+func (h *BookmarkHandler) GetReadingList(c *gin.Context) {
+	viewerID, _ := middleware.GetUserIDFromContext(c)
+	listID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid reading list ID"})
+		return
+	}
+	limit := getLimit(c)
+
+	list, items, page, err := h.bookmarkUseCase.GetReadingList(listID, viewerID, c.Query("cursor"), limit, c.Query("count") == "true")
+	if err != nil {
+		c.JSON(bookmarkErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reading_list": list,
+		"items":        newCursorPaginationResponse(items, limit, page),
+	})
+}
+
+func (h *BookmarkHandler) UpdateReadingList(c *gin.Context) {
+	userID, listID, ok := h.readingListRequest(c)
+	if !ok {
+		return
+	}
+
+	var req domain.UpdateReadingListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid request data: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return
+	}
+
+	list, err := h.bookmarkUseCase.UpdateReadingList(listID, userID, &req)
+	if err != nil {
+		c.JSON(bookmarkErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "Reading list updated successfully",
+		"reading_list": list,
+	})
+}
+
+func (h *BookmarkHandler) DeleteReadingList(c *gin.Context) {
+	userID, listID, ok := h.readingListRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.bookmarkUseCase.DeleteReadingList(listID, userID); err != nil {
+		c.JSON(bookmarkErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Reading list deleted successfully"})
+}
+
+func (h *BookmarkHandler) AddToReadingList(c *gin.Context) {
+	userID, listID, ok := h.readingListRequest(c)
+	if !ok {
+		return
+	}
+
+	blogID, ok := h.bindBlogID(c)
+	if !ok {
+		return
+	}
+
+	item, err := h.bookmarkUseCase.AddToReadingList(listID, userID, blogID)
+	if err != nil {
+		c.JSON(bookmarkErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Blog added to reading list successfully",
+		"item":    item,
+	})
+}
+
+func (h *BookmarkHandler) RemoveFromReadingList(c *gin.Context) {
+	userID, listID, ok := h.readingListRequest(c)
+	if !ok {
+		return
+	}
+	blogID, err := primitive.ObjectIDFromHex(c.Param("blogId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid blog ID"})
+		return
+	}
+
+	if err := h.bookmarkUseCase.RemoveFromReadingList(listID, userID, blogID); err != nil {
+		c.JSON(bookmarkErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Blog removed from reading list successfully"})
+}
+
+func (h *BookmarkHandler) ReorderReadingList(c *gin.Context) {
+	userID, listID, ok := h.readingListRequest(c)
+	if !ok {
+		return
+	}
+
+	blogIDs, ok := h.bindOrder(c)
+	if !ok {
+		return
+	}
+
+	if err := h.bookmarkUseCase.ReorderReadingList(listID, userID, blogIDs); err != nil {
+		c.JSON(bookmarkErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Reading list reordered successfully"})
+}
+
+func (h *BookmarkHandler) readingListRequest(c *gin.Context) (primitive.ObjectID, primitive.ObjectID, bool) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return primitive.NilObjectID, primitive.NilObjectID, false
+	}
+	listID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid reading list ID"})
+		return primitive.NilObjectID, primitive.NilObjectID, false
+	}
+	return userID, listID, true
+}
+
+func (h *BookmarkHandler) bindBlogID(c *gin.Context) (primitive.ObjectID, bool) {
+	var req domain.BookmarkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid request data: " + err.Error()})
+		return primitive.NilObjectID, false
+	}
+	if err := h.validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return primitive.NilObjectID, false
+	}
+	blogID, err := primitive.ObjectIDFromHex(req.BlogID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid blog ID"})
+		return primitive.NilObjectID, false
+	}
+	return blogID, true
+}
+
+func (h *BookmarkHandler) bindOrder(c *gin.Context) ([]primitive.ObjectID, bool) {
+	var req domain.ReorderBookmarksRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid request data: " + err.Error()})
+		return nil, false
+	}
+	if err := h.validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return nil, false
+	}
+	blogIDs, err := parseObjectIDs(req.BlogIDs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid blog ID"})
+		return nil, false
+	}
+	return blogIDs, true
+}
+
+// maps bookmark and reading list errors to HTTP status codes
+func bookmarkErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "not found"):
+		return http.StatusNotFound
+	case strings.Contains(err.Error(), "forbidden"):
+		return http.StatusForbidden
+	case strings.Contains(err.Error(), "invalid"):
+		return http.StatusBadRequest
+	case strings.Contains(err.Error(), "already saved"):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
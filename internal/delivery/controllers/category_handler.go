@@ -0,0 +1,131 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"Blog-API/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CategoryHandler struct {
+	categoryUseCase domain.CategoryUseCase
+	validate        *validator.Validate
+}
+
+func NewCategoryHandler(categoryUseCase domain.CategoryUseCase) *CategoryHandler {
+	return &CategoryHandler{
+		categoryUseCase: categoryUseCase,
+		validate:        validator.New(),
+	}
+}
+
+func (h *CategoryHandler) GetCategoryTree(c *gin.Context) {
+	categories, err := h.categoryUseCase.GetCategoryTree()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"categories": categories})
+}
+
+// shows a category, looked up by ID or slug, with the blogs in it and its subcategories
+func (h *CategoryHandler) GetCategory(c *gin.Context) {
+	page, limit := getPagination(c)
+
+	category, blogs, total, err := h.categoryUseCase.GetCategory(c.Param("id"), page, limit)
+	if err != nil {
+		c.JSON(categoryErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"category": category,
+		"blogs":    newPaginationResponse(blogs, page, limit, total),
+	})
+}
+
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	var req domain.CreateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid request data: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return
+	}
+
+	category, err := h.categoryUseCase.CreateCategory(&req)
+	if err != nil {
+		c.JSON(categoryErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "Category created successfully",
+		"category": category,
+	})
+}
+
+// renames, reorders or moves a category together with its subcategories
+func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid category ID"})
+		return
+	}
+
+	var req domain.UpdateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid request data: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return
+	}
+
+	category, err := h.categoryUseCase.UpdateCategory(id, &req)
+	if err != nil {
+		c.JSON(categoryErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Category updated successfully",
+		"category": category,
+	})
+}
+
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid category ID"})
+		return
+	}
+
+	if err := h.categoryUseCase.DeleteCategory(id); err != nil {
+		c.JSON(categoryErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Category deleted successfully"})
+}
+
+// maps category errors to HTTP status codes
+func categoryErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "not found"):
+		return http.StatusNotFound
+	case strings.Contains(err.Error(), "invalid"):
+		return http.StatusBadRequest
+	case strings.Contains(err.Error(), "already exists"):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,177 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/middleware"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (h *BlogHandler) InviteCollaborator(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	blogID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid blog ID"})
+		return
+	}
+
+	var req domain.InviteCollaboratorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid request data: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return
+	}
+
+	collaborator, err := h.blogUseCase.InviteCollaborator(blogID, req.Username, userID)
+	if err != nil {
+		c.JSON(collaboratorErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":      "Collaborator invited successfully",
+		"collaborator": collaborator,
+	})
+}
+
+func (h *BlogHandler) AcceptCollaboration(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	blogID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid blog ID"})
+		return
+	}
+
+	if err := h.blogUseCase.AcceptCollaboration(blogID, userID); err != nil {
+		c.JSON(collaboratorErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Invitation accepted",
+	})
+}
+
+func (h *BlogHandler) DeclineCollaboration(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	blogID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid blog ID"})
+		return
+	}
+
+	if err := h.blogUseCase.DeclineCollaboration(blogID, userID); err != nil {
+		c.JSON(collaboratorErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Invitation declined",
+	})
+}
+
+func (h *BlogHandler) RemoveCollaborator(c *gin.Context) {
+	userID, userExists := middleware.GetUserIDFromContext(c)
+	userRole, roleExists := middleware.GetUserRoleFromContext(c)
+	if !userExists || !roleExists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	blogID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid blog ID"})
+		return
+	}
+	collaboratorID, err := primitive.ObjectIDFromHex(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid user ID"})
+		return
+	}
+
+	if err := h.blogUseCase.RemoveCollaborator(blogID, collaboratorID, userID, userRole); err != nil {
+		c.JSON(collaboratorErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Collaborator removed successfully",
+	})
+}
+
+func (h *BlogHandler) GetCollaborators(c *gin.Context) {
+	userID, userExists := middleware.GetUserIDFromContext(c)
+	userRole, roleExists := middleware.GetUserRoleFromContext(c)
+	if !userExists || !roleExists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	blogID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid blog ID"})
+		return
+	}
+
+	collaborators, err := h.blogUseCase.GetCollaborators(blogID, userID, userRole)
+	if err != nil {
+		c.JSON(collaboratorErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"collaborators": collaborators,
+	})
+}
+
+func (h *BlogHandler) GetPendingInvitations(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	blogs, err := h.blogUseCase.GetPendingInvitations(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"invitations": blogs,
+	})
+}
+
+func collaboratorErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "not found"):
+		return http.StatusNotFound
+	case strings.Contains(err.Error(), "forbidden"):
+		return http.StatusForbidden
+	case strings.Contains(err.Error(), "invalid"):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,166 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ModerationHandler struct {
+	moderationUseCase domain.ModerationUseCase
+	validate          *validator.Validate
+}
+
+func NewModerationHandler(moderationUseCase domain.ModerationUseCase) *ModerationHandler {
+	return &ModerationHandler{
+		moderationUseCase: moderationUseCase,
+		validate:          validator.New(),
+	}
+}
+
+func (h *ModerationHandler) GetQueue(c *gin.Context) {
+	userID, userExists := middleware.GetUserIDFromContext(c)
+	userRole, roleExists := middleware.GetUserRoleFromContext(c)
+	if !userExists || !roleExists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+	page, limit := getPagination(c)
+
+	comments, total, err := h.moderationUseCase.GetQueue(userID, userRole, page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newPaginationResponse(comments, page, limit, total))
+}
+
+func (h *ModerationHandler) ApproveComment(c *gin.Context) {
+	h.moderate(c, domain.ModerationApprove)
+}
+
+func (h *ModerationHandler) RejectComment(c *gin.Context) {
+	h.moderate(c, domain.ModerationReject)
+}
+
+func (h *ModerationHandler) MarkCommentSpam(c *gin.Context) {
+	h.moderate(c, domain.ModerationSpam)
+}
+
+func (h *ModerationHandler) moderate(c *gin.Context, action string) {
+	userID, userExists := middleware.GetUserIDFromContext(c)
+	userRole, roleExists := middleware.GetUserRoleFromContext(c)
+	if !userExists || !roleExists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	commentID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid comment ID"})
+		return
+	}
+
+	comment, err := h.moderationUseCase.ModerateComment(commentID, action, userID, userRole)
+	if err != nil {
+		c.JSON(moderationErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Comment moderated successfully",
+		"comment": comment,
+	})
+}
+
+func (h *ModerationHandler) GetSettings(c *gin.Context) {
+	mode, err := h.moderationUseCase.GetSiteCommentMode()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"comment_mode": mode,
+	})
+}
+
+func (h *ModerationHandler) UpdateSettings(c *gin.Context) {
+	var req domain.CommentModeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid request data: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return
+	}
+
+	if err := h.moderationUseCase.SetSiteCommentMode(req.Mode); err != nil {
+		c.JSON(moderationErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "Comment mode updated successfully",
+		"comment_mode": req.Mode,
+	})
+}
+
+func (h *ModerationHandler) SetBlogCommentMode(c *gin.Context) {
+	userID, userExists := middleware.GetUserIDFromContext(c)
+	userRole, roleExists := middleware.GetUserRoleFromContext(c)
+	if !userExists || !roleExists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	blogID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid blog ID"})
+		return
+	}
+
+	var req domain.BlogCommentModeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid request data: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return
+	}
+
+	mode := req.Mode
+	if mode == "inherit" {
+		mode = ""
+	}
+	if err := h.moderationUseCase.SetBlogCommentMode(blogID, mode, userID, userRole); err != nil {
+		c.JSON(moderationErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "Comment mode updated successfully",
+		"comment_mode": req.Mode,
+	})
+}
+
+func moderationErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "not found"):
+		return http.StatusNotFound
+	case strings.Contains(err.Error(), "forbidden"):
+		return http.StatusForbidden
+	case strings.Contains(err.Error(), "invalid"):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// public profile, the target of @mention links
+func (h *UserHandler) GetPublicProfile(c *gin.Context) {
+	profile, err := h.userUseCase.GetPublicProfile(c.Param("username"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, domain.ErrorResponse{Error: "User not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+func (h *UserHandler) BlockUser(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	if err := h.userUseCase.BlockUser(userID, c.Param("username")); err != nil {
+		c.JSON(blockErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User blocked successfully"})
+}
+
+func (h *UserHandler) UnblockUser(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	if err := h.userUseCase.UnblockUser(userID, c.Param("username")); err != nil {
+		c.JSON(blockErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User unblocked successfully"})
+}
+
+// maps block errors to HTTP status codes
+func blockErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "not found"):
+		return http.StatusNotFound
+	case strings.Contains(err.Error(), "invalid"):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
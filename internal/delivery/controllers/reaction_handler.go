@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/middleware"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// toggles the caller's reaction of the type given in the body
+func (h *BlogHandler) ReactToBlog(c *gin.Context) {
+	var req domain.ReactToBlogRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid request body"})
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return
+	}
+	h.react(c, req.ReactionType)
+}
+
+func (h *BlogHandler) LikeBlog(c *gin.Context) {
+	h.react(c, domain.ReactionLike)
+}
+
+func (h *BlogHandler) DislikeBlog(c *gin.Context) {
+	h.react(c, domain.ReactionDislike)
+}
+
+func (h *BlogHandler) react(c *gin.Context, reactionType string) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	blogID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid blog ID"})
+		return
+	}
+
+	state, err := h.blogUseCase.ReactToBlog(blogID, userID, reactionType)
+	if err != nil {
+		c.JSON(reactionErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Reaction updated successfully",
+		"my_reaction": state.MyReaction,
+		"counts":      state.Counts,
+	})
+}
+
+// lists who reacted, optionally filtered with ?type=, along with the counts and the caller's own reaction
+func (h *BlogHandler) GetReactions(c *gin.Context) {
+	blogID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid blog ID"})
+		return
+	}
+	page, limit := getPagination(c)
+
+	// set by OptionalAuth when the caller is logged in
+	viewerID, _ := middleware.GetUserIDFromContext(c)
+
+	reactions, total, summary, err := h.blogUseCase.GetReactions(blogID, viewerID, c.Query("type"), page, limit)
+	if err != nil {
+		c.JSON(reactionErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"counts":      summary.Counts,
+		"types":       summary.Types,
+		"my_reaction": summary.MyReaction,
+		"reactions":   newPaginationResponse(reactions, page, limit, total),
+	})
+}
+
+func reactionErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "not found"):
+		return http.StatusNotFound
+	case strings.Contains(err.Error(), "invalid"):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"Blog-API/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	searchUseCase domain.SearchUseCase
+}
+
+func NewSearchHandler(searchUseCase domain.SearchUseCase) *SearchHandler {
+	return &SearchHandler{
+		searchUseCase: searchUseCase,
+	}
+}
+
+// searches the search index with typo tolerance and facet counts, e.g.
+// ?q=kubernets deploy&tags=devops&author=jane&year=2024
+func (h *SearchHandler) Search(c *gin.Context) {
+	page, limit := getPagination(c)
+	query := &domain.SearchQuery{
+		Query:  c.Query("q"),
+		Tags:   splitList(c.Query("tags")),
+		Author: strings.TrimSpace(c.Query("author")),
+		Page:   page,
+		Limit:  limit,
+	}
+	if year := c.Query("year"); year != "" {
+		var err error
+		if query.Year, err = strconv.Atoi(year); err != nil || query.Year < 1 {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid year"})
+			return
+		}
+	}
+
+	blogs, total, facets, err := h.searchUseCase.Search(query)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "invalid") {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"blogs":  newPaginationResponse(blogs, page, limit, total),
+		"facets": facets,
+	})
+}
@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/middleware"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (h *BlogHandler) CreateSeries(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	var req domain.CreateSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid request data: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return
+	}
+
+	blogIDs, err := parseObjectIDs(req.BlogIDs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid blog ID"})
+		return
+	}
+
+	series := &domain.Series{
+		Title:       req.Title,
+		Description: req.Description,
+	}
+	if err := h.blogUseCase.CreateSeries(series, blogIDs, userID); err != nil {
+		c.JSON(seriesErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Series created successfully",
+		"series":  series,
+	})
+}
+
+func (h *BlogHandler) GetSeries(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid series ID"})
+		return
+	}
+	page, limit := getPagination(c)
+
+	series, blogs, total, err := h.blogUseCase.GetSeries(id, page, limit)
+	if err != nil {
+		c.JSON(seriesErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+	viewerID, _ := middleware.GetUserIDFromContext(c)
+	h.blogUseCase.MarkBookmarked(viewerID, blogs...)
+
+	c.JSON(http.StatusOK, gin.H{
+		"series": series,
+		"blogs":  newPaginationResponse(blogs, page, limit, total),
+	})
+}
+
+func (h *BlogHandler) ListSeries(c *gin.Context) {
+	page, limit := getPagination(c)
+
+	series, total, err := h.blogUseCase.ListSeries(page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newPaginationResponse(series, page, limit, total))
+}
+
+func (h *BlogHandler) UpdateSeries(c *gin.Context) {
+	userID, userExists := middleware.GetUserIDFromContext(c)
+	userRole, roleExists := middleware.GetUserRoleFromContext(c)
+	if !userExists || !roleExists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid series ID"})
+		return
+	}
+
+	var req domain.UpdateSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid request data: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return
+	}
+
+	series, err := h.blogUseCase.UpdateSeries(id, req.Title, req.Description, userID, userRole)
+	if err != nil {
+		c.JSON(seriesErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Series updated successfully",
+		"series":  series,
+	})
+}
+
+func (h *BlogHandler) ReorderSeries(c *gin.Context) {
+	userID, userExists := middleware.GetUserIDFromContext(c)
+	userRole, roleExists := middleware.GetUserRoleFromContext(c)
+	if !userExists || !roleExists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid series ID"})
+		return
+	}
+
+	var req domain.ReorderSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid request data: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return
+	}
+
+	blogIDs, err := parseObjectIDs(req.BlogIDs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid blog ID"})
+		return
+	}
+
+	series, err := h.blogUseCase.ReorderSeries(id, blogIDs, userID, userRole)
+	if err != nil {
+		c.JSON(seriesErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Series reordered successfully",
+		"series":  series,
+	})
+}
+
+func (h *BlogHandler) DeleteSeries(c *gin.Context) {
+	userID, userExists := middleware.GetUserIDFromContext(c)
+	userRole, roleExists := middleware.GetUserRoleFromContext(c)
+	if !userExists || !roleExists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid series ID"})
+		return
+	}
+
+	if err := h.blogUseCase.DeleteSeries(id, userID, userRole); err != nil {
+		c.JSON(seriesErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Series deleted successfully",
+	})
+}
+
+func seriesErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "not found"):
+		return http.StatusNotFound
+	case strings.Contains(err.Error(), "forbidden"):
+		return http.StatusForbidden
+	case strings.Contains(err.Error(), "invalid"):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
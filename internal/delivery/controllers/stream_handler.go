@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/middleware"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// most blogs a single connection may watch
+const maxWatchedBlogs = 50
+
+type StreamHandler struct {
+	pubsub    domain.PubSub
+	heartbeat time.Duration
+}
+
+func NewStreamHandler(pubsub domain.PubSub, heartbeat time.Duration) *StreamHandler {
+	if heartbeat <= 0 {
+		heartbeat = 15 * time.Second
+	}
+	return &StreamHandler{
+		pubsub:    pubsub,
+		heartbeat: heartbeat,
+	}
+}
+
+// streams Server-Sent Events: the user's notifications plus new comments and reaction
+// counts for the blogs listed in ?watch=<id>,<id>. Clients resume with Last-Event-ID.
+func (h *StreamHandler) Stream(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	topics := []string{domain.UserTopic(userID)}
+	if watch := c.Query("watch"); watch != "" {
+		ids := strings.Split(watch, ",")
+		if len(ids) > maxWatchedBlogs {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: fmt.Sprintf("Cannot watch more than %d blogs", maxWatchedBlogs)})
+			return
+		}
+		for _, id := range ids {
+			blogID, err := primitive.ObjectIDFromHex(strings.TrimSpace(id))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid blog ID"})
+				return
+			}
+			topics = append(topics, domain.BlogTopic(blogID))
+		}
+	}
+
+	// browsers send the header on reconnect, other clients may use the query parameter
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	var since uint64
+	if lastEventID != "" {
+		id, err := strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid Last-Event-ID"})
+			return
+		}
+		since = id
+	}
+
+	events, cancel := h.pubsub.Subscribe(topics, since)
+	defer cancel()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // keep proxies from buffering the stream
+	c.Status(http.StatusOK)
+	fmt.Fprint(c.Writer, "retry: 3000\n\n")
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				// dropped by the hub for falling behind; the client reconnects and resumes
+				return
+			}
+			fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data)
+			c.Writer.Flush()
+		case <-ticker.C:
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
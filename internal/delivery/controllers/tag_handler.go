@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"Blog-API/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type TagHandler struct {
+	tagUseCase domain.TagUseCase
+	validate   *validator.Validate
+}
+
+func NewTagHandler(tagUseCase domain.TagUseCase) *TagHandler {
+	return &TagHandler{
+		tagUseCase: tagUseCase,
+		validate:   validator.New(),
+	}
+}
+
+// lists tags, most used first
+func (h *TagHandler) GetPopularTags(c *gin.Context) {
+	page, limit := getPagination(c)
+
+	tags, total, err := h.tagUseCase.GetPopularTags(page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newPaginationResponse(tags, page, limit, total))
+}
+
+func (h *TagHandler) GetTag(c *gin.Context) {
+	page, limit := getPagination(c)
+
+	tag, blogs, total, err := h.tagUseCase.GetTag(c.Param("name"), page, limit)
+	if err != nil {
+		c.JSON(tagErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tag":   tag,
+		"blogs": newPaginationResponse(blogs, page, limit, total),
+	})
+}
+
+func (h *TagHandler) CreateTag(c *gin.Context) {
+	var req domain.CreateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid request data: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return
+	}
+
+	tag, err := h.tagUseCase.CreateTag(&req)
+	if err != nil {
+		c.JSON(tagErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Tag created successfully",
+		"tag":     tag,
+	})
+}
+
+// updates the description and renames the tag, rewriting the blogs that use it
+func (h *TagHandler) UpdateTag(c *gin.Context) {
+	var req domain.UpdateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid request data: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return
+	}
+
+	tag, err := h.tagUseCase.UpdateTag(c.Param("name"), &req)
+	if err != nil {
+		c.JSON(tagErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tag updated successfully",
+		"tag":     tag,
+	})
+}
+
+func (h *TagHandler) MergeTags(c *gin.Context) {
+	var req domain.MergeTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid request data: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return
+	}
+
+	tag, err := h.tagUseCase.MergeTags(c.Param("name"), req.Into)
+	if err != nil {
+		c.JSON(tagErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tags merged successfully",
+		"tag":     tag,
+	})
+}
+
+// completes a partly typed tag, e.g. ?q=kube
+func (h *TagHandler) Autocomplete(c *gin.Context) {
+	tags, err := h.tagUseCase.Autocomplete(c.Query("q"), getLimit(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tags": tags})
+}
+
+// proposes tags for a draft from its title and content
+func (h *TagHandler) SuggestTags(c *gin.Context) {
+	var req domain.SuggestTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid request data: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return
+	}
+
+	tags, err := h.tagUseCase.SuggestTags(&req, getLimit(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tags": tags})
+}
+
+func (h *TagHandler) AddSynonym(c *gin.Context) {
+	var req domain.TagSynonymRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid request data: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return
+	}
+
+	synonym, err := h.tagUseCase.AddSynonym(c.Param("name"), req.Synonym)
+	if err != nil {
+		c.JSON(tagErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Synonym added successfully",
+		"synonym": synonym,
+	})
+}
+
+func (h *TagHandler) RemoveSynonym(c *gin.Context) {
+	if err := h.tagUseCase.RemoveSynonym(c.Param("name"), c.Param("synonym")); err != nil {
+		c.JSON(tagErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Synonym removed successfully"})
+}
+
+// maps tag errors to HTTP status codes
+func tagErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "not found"):
+		return http.StatusNotFound
+	case strings.Contains(err.Error(), "invalid"):
+		return http.StatusBadRequest
+	case strings.Contains(err.Error(), "already exists"):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
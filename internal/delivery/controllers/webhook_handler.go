@@ -0,0 +1,209 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type WebhookHandler struct {
+	webhookUseCase domain.WebhookUseCase
+	validate       *validator.Validate
+}
+
+func NewWebhookHandler(webhookUseCase domain.WebhookUseCase) *WebhookHandler {
+	return &WebhookHandler{
+		webhookUseCase: webhookUseCase,
+		validate:       validator.New(),
+	}
+}
+
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	userID, userExists := middleware.GetUserIDFromContext(c)
+	userRole, roleExists := middleware.GetUserRoleFromContext(c)
+	if !userExists || !roleExists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	var req domain.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid request data: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return
+	}
+	if !isHTTPURL(req.URL) {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Webhook URL must use http or https"})
+		return
+	}
+
+	webhook, err := h.webhookUseCase.CreateWebhook(userID, userRole, &req)
+	if err != nil {
+		c.JSON(webhookErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Webhook created successfully; store the secret now, it is not shown again",
+		"webhook": webhook,
+	})
+}
+
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	webhooks, err := h.webhookUseCase.ListWebhooks(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"webhooks": webhooks})
+}
+
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	userID, userRole, webhookID, ok := h.webhookRequest(c)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhookUseCase.GetWebhook(webhookID, userID, userRole)
+	if err != nil {
+		c.JSON(webhookErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	userID, userRole, webhookID, ok := h.webhookRequest(c)
+	if !ok {
+		return
+	}
+
+	var req domain.UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid request data: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return
+	}
+	if req.URL != nil && !isHTTPURL(*req.URL) {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Webhook URL must use http or https"})
+		return
+	}
+
+	webhook, err := h.webhookUseCase.UpdateWebhook(webhookID, userID, userRole, &req)
+	if err != nil {
+		c.JSON(webhookErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook updated successfully",
+		"webhook": webhook,
+	})
+}
+
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	userID, userRole, webhookID, ok := h.webhookRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookUseCase.DeleteWebhook(webhookID, userID, userRole); err != nil {
+		c.JSON(webhookErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted successfully"})
+}
+
+// delivery log of a webhook, newest first
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	userID, userRole, webhookID, ok := h.webhookRequest(c)
+	if !ok {
+		return
+	}
+	page, limit := getPagination(c)
+
+	deliveries, total, err := h.webhookUseCase.ListDeliveries(webhookID, userID, userRole, page, limit)
+	if err != nil {
+		c.JSON(webhookErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newPaginationResponse(deliveries, page, limit, total))
+}
+
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	userID, userRole, webhookID, ok := h.webhookRequest(c)
+	if !ok {
+		return
+	}
+	deliveryID, err := primitive.ObjectIDFromHex(c.Param("deliveryId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid delivery ID"})
+		return
+	}
+
+	delivery, err := h.webhookUseCase.Redeliver(webhookID, deliveryID, userID, userRole)
+	if err != nil {
+		c.JSON(webhookErrorStatus(err), domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message":  "Redelivery queued",
+		"delivery": delivery,
+	})
+}
+
+// reads the caller and the :id parameter, writing the error response when either is missing
+func (h *WebhookHandler) webhookRequest(c *gin.Context) (primitive.ObjectID, string, primitive.ObjectID, bool) {
+	userID, userExists := middleware.GetUserIDFromContext(c)
+	userRole, roleExists := middleware.GetUserRoleFromContext(c)
+	if !userExists || !roleExists {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "User not authenticated"})
+		return primitive.NilObjectID, "", primitive.NilObjectID, false
+	}
+	webhookID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid webhook ID"})
+		return primitive.NilObjectID, "", primitive.NilObjectID, false
+	}
+	return userID, userRole, webhookID, true
+}
+
+func isHTTPURL(url string) bool {
+	return strings.HasPrefix(url, "https://") || strings.HasPrefix(url, "http://")
+}
+
+// maps webhook errors to HTTP status codes
+func webhookErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "not found"):
+		return http.StatusNotFound
+	case strings.Contains(err.Error(), "forbidden"):
+		return http.StatusForbidden
+	case strings.Contains(err.Error(), "invalid"):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
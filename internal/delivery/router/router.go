@@ -7,9 +7,17 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRouter(userHandler *controllers.UserHandler, blogHandler *controllers.BlogHandler, authMiddleware *middleware.AuthMiddleware) *gin.Engine {
+func SetupRouter(userHandler *controllers.UserHandler, blogHandler *controllers.BlogHandler,
+	moderationHandler *controllers.ModerationHandler, notificationHandler *controllers.NotificationHandler,
+	streamHandler *controllers.StreamHandler, webhookHandler *controllers.WebhookHandler,
+	bookmarkHandler *controllers.BookmarkHandler, tagHandler *controllers.TagHandler,
+	categoryHandler *controllers.CategoryHandler, searchHandler *controllers.SearchHandler, authMiddleware *middleware.AuthMiddleware) *gin.Engine {
 	router := gin.Default()
 
+	// author profiles and permalinks, e.g. /@jane and /@jane/my-first-post
+	router.GET("/@:username", userHandler.GetPublicProfile)
+	router.GET("/@:username/:slug", authMiddleware.OptionalAuth(), blogHandler.GetBlogByPermalink)
+
 	// API v1 routes
 	v1 := router.Group("/api/v1")
 	{
@@ -34,26 +42,34 @@ func SetupRouter(userHandler *controllers.UserHandler, blogHandler *controllers.
 		{
 			users.GET("/profile", userHandler.GetProfile)
 			users.PUT("/profile", userHandler.UpdateProfile)
+			users.GET("/invitations", blogHandler.GetPendingInvitations)
+			users.GET("/trash", blogHandler.GetTrash)
+			users.POST("/blocks/:username", userHandler.BlockUser)
+			users.DELETE("/blocks/:username", userHandler.UnblockUser)
 		}
 		// blog routes
 		blogs := v1.Group("/blogs")
 		{
 			// public routes (no auth)
-			blogs.GET("/", blogHandler.GetAllBlogs)
-			blogs.GET("/:id", blogHandler.GetBlog)
-			blogs.GET("/popular", blogHandler.GetPopularBlogs)
+			blogs.GET("/", authMiddleware.OptionalAuth(), blogHandler.GetAllBlogs)
+			blogs.GET("/:id", authMiddleware.OptionalAuth(), blogHandler.GetBlog)
+			blogs.GET("/popular", authMiddleware.OptionalAuth(), blogHandler.GetPopularBlogs)
+			blogs.GET("/by-slug/:slug", authMiddleware.OptionalAuth(), blogHandler.GetBlogBySlug)
+			blogs.GET("/:id/comments", authMiddleware.OptionalAuth(), blogHandler.GetComments)
+			blogs.GET("/:id/reactions", authMiddleware.OptionalAuth(), blogHandler.GetReactions)
 
 			//search and filter routes
+			blogs.GET("/search", authMiddleware.OptionalAuth(), blogHandler.SearchBlogs)
 			search := blogs.Group("/search")
 			{
-				search.GET("/title", blogHandler.SearchBlogsByTitle)
-				search.GET("/author", blogHandler.SearchBlogsByAuthor)
+				search.GET("/title", authMiddleware.OptionalAuth(), blogHandler.SearchBlogsByTitle)
+				search.GET("/author", authMiddleware.OptionalAuth(), blogHandler.SearchBlogsByAuthor)
 			}
 
 			filter := blogs.Group("/filter")
 			{
-				filter.GET("/tags", blogHandler.FilterBlogsByTags)
-				filter.GET("/date", blogHandler.FilterBlogsByDate)
+				filter.GET("/tags", authMiddleware.OptionalAuth(), blogHandler.FilterBlogsByTags)
+				filter.GET("/date", authMiddleware.OptionalAuth(), blogHandler.FilterBlogsByDate)
 			}
 
 			// protected routes (auth required)
@@ -61,17 +77,147 @@ func SetupRouter(userHandler *controllers.UserHandler, blogHandler *controllers.
 			blogs.POST("/", blogHandler.CreateBlog)
 			blogs.PUT("/:id", blogHandler.UpdateBlog)
 			blogs.DELETE("/:id", blogHandler.DeleteBlog)
+			blogs.POST("/:id/restore", blogHandler.RestoreBlog)
 
 			//comments
 
 			blogs.POST("/:id/comments", blogHandler.AddComment)
 			blogs.PUT("/:id/comments/:commentId", blogHandler.UpdateComment)
 			blogs.DELETE("/:id/comments/:commentId", blogHandler.DeleteComment)
+			blogs.PUT("/:id/comment-mode", moderationHandler.SetBlogCommentMode)
+
+			//collaborators
+			blogs.GET("/:id/collaborators", blogHandler.GetCollaborators)
+			blogs.POST("/:id/collaborators", blogHandler.InviteCollaborator)
+			blogs.POST("/:id/collaborators/accept", blogHandler.AcceptCollaboration)
+			blogs.POST("/:id/collaborators/decline", blogHandler.DeclineCollaboration)
+			blogs.DELETE("/:id/collaborators/:userId", blogHandler.RemoveCollaborator)
 
 			//Reactions
+			blogs.POST("/:id/reactions", blogHandler.ReactToBlog)
 			blogs.POST("/:id/like", blogHandler.LikeBlog)
 			blogs.POST("/:id/dislike", blogHandler.DislikeBlog)
 		}
+
+		// series routes
+		series := v1.Group("/series")
+		{
+			series.GET("/", blogHandler.ListSeries)
+			series.GET("/:id", authMiddleware.OptionalAuth(), blogHandler.GetSeries)
+
+			series.Use(authMiddleware.AuthRequired())
+			series.POST("/", blogHandler.CreateSeries)
+			series.PUT("/:id", blogHandler.UpdateSeries)
+			series.PUT("/:id/order", blogHandler.ReorderSeries)
+			series.DELETE("/:id", blogHandler.DeleteSeries)
+		}
+
+		// tags (creating, renaming, merging them and managing synonyms is admin only)
+		tags := v1.Group("/tags")
+		{
+			tags.GET("/", tagHandler.GetPopularTags)
+			tags.GET("/autocomplete", tagHandler.Autocomplete)
+			tags.GET("/:name", tagHandler.GetTag)
+			tags.POST("/suggestions", authMiddleware.AuthRequired(), tagHandler.SuggestTags)
+		}
+
+		tagsAdmin := v1.Group("/tags")
+		tagsAdmin.Use(authMiddleware.AdminRequired())
+		{
+			tagsAdmin.POST("/", tagHandler.CreateTag)
+			tagsAdmin.PUT("/:name", tagHandler.UpdateTag)
+			tagsAdmin.POST("/:name/merge", tagHandler.MergeTags)
+			tagsAdmin.POST("/:name/synonyms", tagHandler.AddSynonym)
+			tagsAdmin.DELETE("/:name/synonyms/:synonym", tagHandler.RemoveSynonym)
+		}
+
+		// search index with typo tolerance and facets; /blogs/search queries Mongo directly
+		v1.GET("/search", searchHandler.Search)
+
+		// categories (the tree is curated by admins)
+		categories := v1.Group("/categories")
+		{
+			categories.GET("/", categoryHandler.GetCategoryTree)
+			categories.GET("/:id", categoryHandler.GetCategory)
+		}
+
+		categoriesAdmin := v1.Group("/categories")
+		categoriesAdmin.Use(authMiddleware.AdminRequired())
+		{
+			categoriesAdmin.POST("/", categoryHandler.CreateCategory)
+			categoriesAdmin.PUT("/:id", categoryHandler.UpdateCategory)
+			categoriesAdmin.DELETE("/:id", categoryHandler.DeleteCategory)
+		}
+
+		// private bookmarks
+		bookmarks := v1.Group("/bookmarks")
+		bookmarks.Use(authMiddleware.AuthRequired())
+		{
+			bookmarks.GET("/", bookmarkHandler.GetBookmarks)
+			bookmarks.POST("/", bookmarkHandler.AddBookmark)
+			bookmarks.DELETE("/:blogId", bookmarkHandler.RemoveBookmark)
+			bookmarks.PUT("/order", bookmarkHandler.ReorderBookmarks)
+		}
+
+		// reading lists (public lists can be read by anyone)
+		readingLists := v1.Group("/reading-lists")
+		{
+			readingLists.GET("/", authMiddleware.OptionalAuth(), bookmarkHandler.GetReadingLists)
+			readingLists.GET("/:id", authMiddleware.OptionalAuth(), bookmarkHandler.GetReadingList)
+
+			readingLists.Use(authMiddleware.AuthRequired())
+			readingLists.POST("/", bookmarkHandler.CreateReadingList)
+			readingLists.PUT("/:id", bookmarkHandler.UpdateReadingList)
+			readingLists.DELETE("/:id", bookmarkHandler.DeleteReadingList)
+			readingLists.POST("/:id/items", bookmarkHandler.AddToReadingList)
+			readingLists.DELETE("/:id/items/:blogId", bookmarkHandler.RemoveFromReadingList)
+			readingLists.PUT("/:id/order", bookmarkHandler.ReorderReadingList)
+		}
+
+		// real-time updates over Server-Sent Events
+		v1.GET("/stream", authMiddleware.AuthRequired(), streamHandler.Stream)
+
+		// notification center
+		notifications := v1.Group("/notifications")
+		notifications.Use(authMiddleware.AuthRequired())
+		{
+			notifications.GET("/", notificationHandler.GetNotifications)
+			notifications.GET("/unread-count", notificationHandler.GetUnreadCount)
+			notifications.POST("/:id/read", notificationHandler.MarkRead)
+			notifications.POST("/read-all", notificationHandler.MarkAllRead)
+			notifications.GET("/preferences", notificationHandler.GetPreferences)
+			notifications.PUT("/preferences", notificationHandler.UpdatePreferences)
+		}
+
+		// outbound webhooks (global webhooks are admin only)
+		webhooks := v1.Group("/webhooks")
+		webhooks.Use(authMiddleware.AuthRequired())
+		{
+			webhooks.POST("/", webhookHandler.CreateWebhook)
+			webhooks.GET("/", webhookHandler.ListWebhooks)
+			webhooks.GET("/:id", webhookHandler.GetWebhook)
+			webhooks.PUT("/:id", webhookHandler.UpdateWebhook)
+			webhooks.DELETE("/:id", webhookHandler.DeleteWebhook)
+			webhooks.GET("/:id/deliveries", webhookHandler.ListDeliveries)
+			webhooks.POST("/:id/deliveries/:deliveryId/redeliver", webhookHandler.Redeliver)
+		}
+
+		// moderation routes (post owners see their own queue, moderators see everything)
+		moderation := v1.Group("/moderation")
+		moderation.Use(authMiddleware.AuthRequired())
+		{
+			moderation.GET("/comments", moderationHandler.GetQueue)
+			moderation.POST("/comments/:id/approve", moderationHandler.ApproveComment)
+			moderation.POST("/comments/:id/reject", moderationHandler.RejectComment)
+			moderation.POST("/comments/:id/spam", moderationHandler.MarkCommentSpam)
+		}
+
+		moderationAdmin := v1.Group("/moderation")
+		moderationAdmin.Use(authMiddleware.AdminRequired())
+		{
+			moderationAdmin.GET("/settings", moderationHandler.GetSettings)
+			moderationAdmin.PUT("/settings", moderationHandler.UpdateSettings)
+		}
 	}
 
 	return router
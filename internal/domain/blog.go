@@ -1,41 +1,96 @@
 package domain
 
 import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type Blog struct {
-	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
-	Title          string             `bson:"title" json:"title" validate:"required,min=1,max=200"`
-	Content        string             `bson:"content" json:"content" validate:"required,min=1"`
-	AuthorID       primitive.ObjectID `bson:"author_id" json:"author_id"`
-	AuthorUsername string             `bson:"author_username" json:"author_username"`
-	Tags           []string           `bson:"tags,omitempty" json:"tags,omitempty"`
-	ViewCount      int                `bson:"view_count" json:"view_count"`
-	LikeCount      int                `bson:"like_count" json:"like_count"`
-	CommentCount   int                `bson:"comment_count" json:"comment_count"`
-	Likes          []string           `bson:"likes,omitempty" json:"likes,omitempty"`
-	Dislikes       []string           `bson:"dislikes,omitempty" json:"dislikes,omitempty"`
-	Comments       []Comment          `bson:"comments,omitempty" json:"comments,omitempty"`
-	CreatedAt      time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt      time.Time          `bson:"updated_at" json:"updated_at"`
-}
-
-type Comment struct {
-	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
-	AuthorID       primitive.ObjectID `bson:"author_id" json:"author_id"`
-	AuthorUsername string             `bson:"author_username" json:"author_username"`
-	Content        string             `bson:"content" json:"content" validate:"required,min=1"`
-	CreatedAt      time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt      time.Time          `bson:"updated_at" json:"updated_at"`
+	ID             primitive.ObjectID   `bson:"_id,omitempty" json:"id,omitempty"`
+	Title          string               `bson:"title" json:"title" validate:"required,min=1,max=200"`
+	Slug           string               `bson:"slug" json:"slug"`
+	SlugHistory    []string             `bson:"slug_history,omitempty" json:"-"` // previous slugs that redirect to Slug
+	Content        string               `bson:"content" json:"content" validate:"required,min=1"`
+	ContentHTML    string               `bson:"content_html" json:"content_html"`
+	Excerpt        string               `bson:"excerpt" json:"excerpt"`
+	WordCount      int                  `bson:"word_count" json:"word_count"`
+	ReadingTime    int                  `bson:"reading_time" json:"reading_time"` // minutes
+	TOC            []TOCEntry           `bson:"toc,omitempty" json:"toc,omitempty"`
+	AuthorID       primitive.ObjectID   `bson:"author_id" json:"author_id"`
+	AuthorUsername string               `bson:"author_username" json:"author_username"`
+	CoAuthors      []string             `bson:"co_authors,omitempty" json:"co_authors,omitempty"` // usernames of accepted collaborators, for bylines
+	Collaborators  []Collaborator       `bson:"collaborators,omitempty" json:"-"`                 // invitations included, see GetCollaborators
+	Tags           []string             `bson:"tags,omitempty" json:"tags,omitempty"`
+	CategoryID     *primitive.ObjectID  `bson:"category_id,omitempty" json:"category_id,omitempty"` // primary category
+	Mentions       []Mention            `bson:"mentions,omitempty" json:"mentions,omitempty"`
+	NotifiedUsers  []primitive.ObjectID `bson:"notified_users,omitempty" json:"-"` // mentioned users already notified, across edits
+	ViewCount      int                  `bson:"view_count" json:"view_count"`
+	LikeCount      int                  `bson:"like_count" json:"like_count"`
+	ReactionCounts map[string]int       `bson:"reaction_counts,omitempty" json:"reaction_counts,omitempty"` // reaction type -> count
+	CommentCount   int                  `bson:"comment_count" json:"comment_count"`
+	CommentMode    string               `bson:"comment_mode,omitempty" json:"comment_mode,omitempty"` // empty inherits the site-wide mode
+	CreatedAt      time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time            `bson:"updated_at" json:"updated_at"`
+	DeletedAt      *time.Time           `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	SeriesNav      *SeriesNavigation    `bson:"-" json:"series,omitempty"`
+	Breadcrumbs    []CategoryCrumb      `bson:"-" json:"category_breadcrumbs,omitempty"`
+	BookmarkedByMe *bool                `bson:"-" json:"bookmarked_by_me,omitempty"` // only set for authenticated callers
+	Search         *SearchMatch         `bson:"-" json:"search,omitempty"`           // only set in search results
 }
 
+// why a blog matched a full-text search
+type SearchMatch struct {
+	Score    float64  `json:"score"`
+	Title    string   `json:"title"`              // HTML escaped, matched terms wrapped in <mark>
+	Snippets []string `json:"snippets,omitempty"` // excerpts of the content around the matches, marked the same way
+}
+
+// user invited to co-author a blog
+type Collaborator struct {
+	UserID     primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Username   string             `bson:"username" json:"username"`
+	Status     string             `bson:"status" json:"status"`
+	InvitedAt  time.Time          `bson:"invited_at" json:"invited_at"`
+	AcceptedAt *time.Time         `bson:"accepted_at,omitempty" json:"accepted_at,omitempty"`
+}
+
+const (
+	CollaboratorPending  = "pending"
+	CollaboratorAccepted = "accepted"
+)
+
+// reports whether the user owns the blog or is an accepted co-author
+func (b *Blog) CanEdit(userID primitive.ObjectID) bool {
+	if b.AuthorID == userID {
+		return true
+	}
+	for _, c := range b.Collaborators {
+		if c.UserID == userID && c.Status == CollaboratorAccepted {
+			return true
+		}
+	}
+	return false
+}
+
+// heading entry in a blog's table of contents
+type TOCEntry struct {
+	Level  int    `bson:"level" json:"level"`
+	Text   string `bson:"text" json:"text"`
+	Anchor string `bson:"anchor" json:"anchor"`
+}
+
+// a user's reaction to a blog; a user has at most one reaction per blog
 type Reaction struct {
 	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
 	BlogID       primitive.ObjectID `bson:"blog_id" json:"blog_id"`
 	UserID       primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Username     string             `bson:"username" json:"username"`
 	ReactionType string             `bson:"reaction_type" json:"reaction_type"`
 	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
 	UpdatedAt    time.Time          `bson:"updated_at" json:"updated_at"`
@@ -46,100 +101,287 @@ const (
 	ReactionDislike = "dislike"
 )
 
-type Tag struct {
-	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
-	Name      string             `bson:"name" json:"name" validate:"required,min=1,max=50"`
-	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+// reaction a user can leave on a blog, e.g. {"clap", "👏"}
+type ReactionType struct {
+	Name  string `json:"name"`
+	Emoji string `json:"emoji"`
+}
+
+// reaction types offered when none are configured
+var DefaultReactionTypes = []string{"like:👍", "dislike:👎", "clap:👏", "heart:❤️", "thinking:🤔"}
+
+// parses "name:emoji" specs; names must be lowercase letters and digits, duplicates are ignored
+func ParseReactionTypes(specs []string) ([]ReactionType, error) {
+	types := []ReactionType{}
+	seen := map[string]bool{}
+	for _, spec := range specs {
+		name, emoji, _ := strings.Cut(strings.TrimSpace(spec), ":")
+		if !reactionNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid reaction type %q", spec)
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		types = append(types, ReactionType{Name: name, Emoji: emoji})
+	}
+	return types, nil
+}
+
+var reactionNamePattern = regexp.MustCompile(`^[a-z0-9]{1,20}$`)
+
+// outcome of a reaction toggle: the caller's reaction after it and the fresh counts
+type ReactionState struct {
+	BlogID     primitive.ObjectID `json:"blog_id"`
+	MyReaction string             `json:"my_reaction"` // empty when the reaction was taken back
+	Counts     map[string]int     `json:"counts"`
+}
+
+// reactions on a blog as seen by one viewer
+type ReactionSummary struct {
+	Counts     map[string]int `json:"counts"`
+	Types      []ReactionType `json:"types"`
+	MyReaction string         `json:"my_reaction,omitempty"` // empty when anonymous or not reacted
 }
 
 type BlogRepository interface {
+	WithContext(ctx context.Context) BlogRepository
 	Create(blog *Blog) error
 	GetByID(id primitive.ObjectID) (*Blog, error)
-	GetAll(page, limit int, sort string) ([]*Blog, int64, error)
+	GetBySlug(slug string) (*Blog, error)
+	GetByIDs(ids []primitive.ObjectID) ([]*Blog, error)
+	SlugTaken(slug string, excludeID primitive.ObjectID) (bool, error)
+	// lists the blogs matching every filter in params, which must have been validated
+	List(params *ListBlogParams) ([]*Blog, int64, error)
+	// like List, and counts the tags, authors and months over all matches in the same query
+	ListWithFacets(params *ListBlogParams) ([]*Blog, int64, *BlogFacets, error)
+	// like List, paging with cursors instead of page numbers; params.Page is ignored
+	ListByCursor(params *ListBlogParams, after *Cursor, count bool) ([]*Blog, *CursorPage, error)
+	// blogs outside the trash with an ID above after, in ID order, to walk the whole collection
+	ListAfterID(after primitive.ObjectID, limit int) ([]*Blog, error)
+	// blogs saved before content was rendered on write, trashed ones included, in ID order after after
+	ListUnrendered(after primitive.ObjectID, limit int) ([]*Blog, error)
+	// stores the rendered HTML, the fields derived from it and the mentions, leaving updated_at alone
+	SetRendered(blog *Blog) error
 	Update(blog *Blog) error
 	Delete(id primitive.ObjectID) error
+	GetDeletedByID(id primitive.ObjectID) (*Blog, error)
+	Restore(id primitive.ObjectID) error
+	GetTrash(authorID primitive.ObjectID, page, limit int) ([]*Blog, int64, error)
+	// IDs of the blogs trashed before the cutoff, to be purged one by one
+	ListDeletedBefore(cutoff time.Time) ([]primitive.ObjectID, error)
+	// permanently removes a trashed blog; the comments and such hanging off it go first
+	Purge(id primitive.ObjectID) error
 	SearchByTitle(title string, page, limit int) ([]*Blog, int64, error)
 	SearchByAuthor(author string, page, limit int) ([]*Blog, int64, error)
-	FilterByTags(tags []string, page, limit int) ([]*Blog, int64, error)
-	FilterByDate(startDate, endDate time.Time, page, limit int) ([]*Blog, int64, error)
-	GetPopular(limit int) ([]*Blog, error)
 	IncrementViewCount(id primitive.ObjectID) error
-	AddComment(blogID primitive.ObjectID, comment *Comment) error
-	DeleteComment(blogID, commentID primitive.ObjectID) error
-	UpdateComment(blogID, commentID primitive.ObjectID, content string) error
-	AddLike(blogID primitive.ObjectID, userID string) error
-	RemoveLike(blogID primitive.ObjectID, userID string) error
-	AddDislike(blogID primitive.ObjectID, userID string) error
-	RemoveDislike(blogID primitive.ObjectID, userID string) error
-	GetTagIDByName(name string) (primitive.ObjectID, error)
+	IncrementCommentCount(blogID primitive.ObjectID, delta int) error
+	UpdateCommentMode(blogID primitive.ObjectID, mode string) error
+	// applies the deltas to the per-type counters (and like_count) and returns the updated counters
+	IncrementReactionCounts(blogID primitive.ObjectID, deltas map[string]int) (map[string]int, error)
+	// lists blogs whose primary category is one of categoryIDs, newest first
+	ListByCategories(categoryIDs []primitive.ObjectID, page, limit int) ([]*Blog, int64, error)
+	// removes the category from every blog, used when the category is deleted; returns the IDs of the blogs changed
+	ClearCategory(categoryID primitive.ObjectID) ([]primitive.ObjectID, error)
+	// renames a tag on every blog, trashed ones included; returns the IDs of the blogs changed
+	ReplaceTag(from, to string) ([]primitive.ObjectID, error)
+	// number of blogs outside the trash using the tag
+	CountByTag(name string) (int64, error)
+	// usage of every tag across the blogs outside the trash
+	TagUsage() (map[string]int64, error)
+	// the best scoring blogs written since the given time, or ever for nil, best first
+	TrendingScores(since *time.Time, now time.Time, weights TrendingWeights, limit int) ([]TrendingEntry, error)
+	AddCollaborator(blogID primitive.ObjectID, collaborator *Collaborator) error
+	AcceptCollaborator(blogID, userID primitive.ObjectID) error
+	RemoveCollaborator(blogID, userID primitive.ObjectID) error
+	GetByCollaborator(userID primitive.ObjectID, status string) ([]*Blog, error)
 }
 
 type BlogUseCase interface {
 	CreateBlog(blog *Blog, authorID primitive.ObjectID) error
 	GetBlog(id primitive.ObjectID) (*Blog, error)
-	GetAllBlogs(page, limit int, sort string) ([]*Blog, int64, error)
+	GetBlogBySlug(slug string) (*Blog, error)
+	GetBlogByPermalink(username, slug string) (*Blog, error)
+	// deleted blogs can only be listed by their owners, or by admins
+	// the facets are nil unless params.Facets is set
+	ListBlogs(params *ListBlogParams, viewerID primitive.ObjectID, viewerRole string) ([]*Blog, int64, *BlogFacets, error)
+	// full-text search ranked by relevance, with highlighted titles and snippets; takes the same filters as ListBlogs
+	SearchBlogs(params *ListBlogParams, viewerID primitive.ObjectID, viewerRole string) ([]*Blog, int64, *BlogFacets, error)
+	// like ListBlogs and SearchBlogs, paging with the opaque cursor of a previous page, or
+	// from the first page for an empty one; the matches are only counted when count is set
+	ListBlogsByCursor(params *ListBlogParams, cursor string, count bool, viewerID primitive.ObjectID, viewerRole string) ([]*Blog, *CursorPage, error)
+	SearchBlogsByCursor(params *ListBlogParams, cursor string, count bool, viewerID primitive.ObjectID, viewerRole string) ([]*Blog, *CursorPage, error)
 	UpdateBlog(id primitive.ObjectID, blog *Blog, userID primitive.ObjectID, userRole string) (*Blog, error)
 	DeleteBlog(id primitive.ObjectID, userID primitive.ObjectID, userRole string) error
+	RestoreBlog(id primitive.ObjectID, userID primitive.ObjectID, userRole string) (*Blog, error)
+	GetTrash(userID primitive.ObjectID, page, limit int) ([]*Blog, int64, error)
+	PurgeTrash(retention time.Duration) (int, error)
+	// renders the blogs saved before content was rendered on write and returns how many there were
+	BackfillRendered() (int, error)
 	SearchBlogsByTitle(title string, page, limit int) ([]*Blog, int64, error)
 	SearchBlogsByAuthor(author string, page, limit int) ([]*Blog, int64, error)
 	FilterBlogsByTags(tags []string, page, limit int) ([]*Blog, int64, error)
 	FilterBlogsByDate(startDate, endDate time.Time, page, limit int) ([]*Blog, int64, error)
-	GetPopularBlogs(limit int) ([]*Blog, error)
+	// the blogs of a trending window (TrendingToday, TrendingWeek or TrendingAllTime), best first
+	GetPopularBlogs(window string, limit int) (*TrendingRanking, []*Blog, error)
 	AddComment(blogID primitive.ObjectID, comment *Comment) error
+	GetComments(blogID primitive.ObjectID, parentID *primitive.ObjectID, viewerID primitive.ObjectID, viewerRole string, sort string, page, limit int) ([]*Comment, int64, error)
+	GetCommentsByCursor(blogID primitive.ObjectID, parentID *primitive.ObjectID, viewerID primitive.ObjectID, viewerRole string, sort string, cursor string, limit int, count bool) ([]*Comment, *CursorPage, error)
 	DeleteComment(blogID, commentID primitive.ObjectID, userID primitive.ObjectID) error
 	UpdateComment(blogID, commentID primitive.ObjectID, content string, userID primitive.ObjectID) error
-	LikeBlog(blogID primitive.ObjectID, userID string) error
-	DislikeBlog(blogID primitive.ObjectID, userID string) error
+	ReactToBlog(blogID, userID primitive.ObjectID, reactionType string) (*ReactionState, error)
+	GetReactions(blogID, viewerID primitive.ObjectID, reactionType string, page, limit int) ([]*Reaction, int64, *ReactionSummary, error)
+	CreateSeries(series *Series, blogIDs []primitive.ObjectID, ownerID primitive.ObjectID) error
+	GetSeries(id primitive.ObjectID, page, limit int) (*Series, []*Blog, int64, error)
+	ListSeries(page, limit int) ([]*Series, int64, error)
+	UpdateSeries(id primitive.ObjectID, title, description *string, userID primitive.ObjectID, userRole string) (*Series, error)
+	ReorderSeries(id primitive.ObjectID, blogIDs []primitive.ObjectID, userID primitive.ObjectID, userRole string) (*Series, error)
+	DeleteSeries(id primitive.ObjectID, userID primitive.ObjectID, userRole string) error
+	InviteCollaborator(blogID primitive.ObjectID, username string, userID primitive.ObjectID) (*Collaborator, error)
+	AcceptCollaboration(blogID, userID primitive.ObjectID) error
+	DeclineCollaboration(blogID, userID primitive.ObjectID) error
+	RemoveCollaborator(blogID, collaboratorID, userID primitive.ObjectID, userRole string) error
+	GetPendingInvitations(userID primitive.ObjectID) ([]*Blog, error)
+	// the blog's collaborators and open invitations, for its owner, its collaborators and admins
+	GetCollaborators(blogID, userID primitive.ObjectID, userRole string) ([]Collaborator, error)
+	MarkBookmarked(viewerID primitive.ObjectID, blogs ...*Blog)
 }
 
-// We will think about this later
-
-// type ReactionRepository interface {
-// 	Create(reaction *Reaction) error
-// 	GetByBlogAndUser(blogID, userID primitive.ObjectID) (*Reaction, error)
-// 	Update(reaction *Reaction) error
-// 	Delete(id primitive.ObjectID) error
-// }
-
-// type TagRepository interface {
-// 	Create(tag *Tag) error
-// 	GetByName(name string) (*Tag, error)
-// 	List() ([]*Tag, error)
-// }
+type ReactionRepository interface {
+	WithContext(ctx context.Context) ReactionRepository
+	GetByBlogAndUser(blogID, userID primitive.ObjectID) (*Reaction, error)
+	// sets the user's reaction and returns the one it replaced, nil if there was none
+	Upsert(reaction *Reaction) (*Reaction, error)
+	// removes the user's reaction if it is of reactionType (any type when empty) and returns it,
+	// nil if there was no such reaction
+	Delete(blogID, userID primitive.ObjectID, reactionType string) (*Reaction, error)
+	ListByBlog(blogID primitive.ObjectID, reactionType string, page, limit int) ([]*Reaction, int64, error)
+	DeleteByBlog(blogID primitive.ObjectID) error
+}
 
 type CreateBlogRequest struct {
-	Title   string   `json:"title" validate:"required,min=5,max=255"`
-	Content string   `json:"content" validate:"required,min=20"`
-	Tags    []string `json:"tags" validate:"omitempty,dive,alphanum,min=2,max=20"`
+	Title      string   `json:"title" validate:"required,min=5,max=255"`
+	Content    string   `json:"content" validate:"required,min=20"`
+	Tags       []string `json:"tags" validate:"omitempty,dive,min=2,max=20"`
+	CategoryID string   `json:"category_id" validate:"omitempty,len=24,hexadecimal"`
 }
 
 type UpdateBlogRequest struct {
-	Title   *string   `json:"title" validate:"omitempty,min=5,max=255"`
-	Content *string   `json:"content" validate:"omitempty,min=20"`
-	Tags    *[]string `json:"tags" validate:"omitempty,dive,alphanum,min=2,max=20"`
+	Title      *string   `json:"title" validate:"omitempty,min=5,max=255"`
+	Content    *string   `json:"content" validate:"omitempty,min=20"`
+	Tags       *[]string `json:"tags" validate:"omitempty,dive,min=2,max=20"`
+	CategoryID *string   `json:"category_id" validate:"omitempty,len=0|len=24"` // empty string removes the category
 }
 
-type CreateCommentRequest struct {
-	Content string `json:"content" validate:"required,min=1,max=2000"`
+type InviteCollaboratorRequest struct {
+	Username string `json:"username" validate:"required,min=3,max=50"`
 }
 
-type UpdateCommentRequest struct {
-	Content string `json:"content" validate:"required,min=1,max=2000"`
+type ReactToBlogRequest struct {
+	ReactionType string `json:"reaction_type" validate:"required,max=20"`
 }
 
-type ReactToBlogRequest struct {
-	ReactionType string `json:"reaction_type" validate:"required,oneof=like dislike"`
+// orders a blog listing can be sorted in
+const (
+	BlogSortNewest    = "newest"
+	BlogSortOldest    = "oldest"
+	BlogSortPopular   = "popular" // most liked first
+	BlogSortViews     = "views"
+	BlogSortComments  = "comments"
+	BlogSortRelevance = "relevance" // best full-text match first, the default when searching
+)
+
+// how blogs must match the tags of a listing
+const (
+	TagMatchAny = "any"
+	TagMatchAll = "all"
+)
+
+// which blogs a listing covers with respect to the trash
+const (
+	BlogStatusPublished = "published" // outside the trash
+	BlogStatusDeleted   = "deleted"   // in the trash
+	BlogStatusAll       = "all"
+)
+
+const (
+	// maximum number of tags a listing can filter by
+	MaxListTags = 10
+	// maximum length of a full-text search query
+	MaxSearchQueryLength = 200
+	// terms listed per facet of a listing
+	BlogFacetSize = 10
+)
+
+// counts over every blog matching a listing's filters, for filter sidebars. Tags and
+// authors come most used first, months ("2024-05") newest first.
+type BlogFacets struct {
+	Tags    []FacetCount `json:"tags"`
+	Authors []FacetCount `json:"authors"`
+	Months  []FacetCount `json:"months"`
 }
 
-// We will think about this in the next tasks
-// type ListBlogParams struct {
-// 	Page       int
-// 	Limit      int
-// 	SortBy     string   // e.g., "newest", "popularity"
-// 	Tags       []string // Filter by tags
-// 	Author     string   // Filter by author username
-// 	SearchTerm string   // For text search on title/content
-// }
+// query behind GET /blogs; every filter is optional and they all combine
+type ListBlogParams struct {
+	Page        int
+	Limit       int
+	Query       string // full-text search over title, tags and content; supports "exact phrases" and -excluded words
+	Tags        []string
+	TagMatch    string              // TagMatchAny (default) or TagMatchAll
+	TagSynonyms map[string][]string // synonyms still found on older blogs, keyed by tag; filled in by the use case
+	Author      string              // username of the author or of an accepted co-author
+	OwnerID     *primitive.ObjectID // only blogs owned by this user
+	From        *time.Time          // created at or after
+	To          *time.Time          // created before
+	Status      string              // BlogStatusPublished (default), BlogStatusDeleted or BlogStatusAll
+	SortBy      string              // BlogSortNewest (default), BlogSortOldest, BlogSortPopular, BlogSortViews, BlogSortComments or BlogSortRelevance
+	Facets      bool                // also count tags, authors and months over all matches
+}
+
+// fills in the defaults and rejects unknown options and empty date ranges
+func (p *ListBlogParams) Validate() error {
+	p.Query = strings.TrimSpace(p.Query)
+	if p.SortBy == "" {
+		p.SortBy = BlogSortNewest
+		if p.Query != "" {
+			p.SortBy = BlogSortRelevance
+		}
+	}
+	if p.TagMatch == "" {
+		p.TagMatch = TagMatchAny
+	}
+	if p.Status == "" {
+		p.Status = BlogStatusPublished
+	}
+
+	switch p.SortBy {
+	case BlogSortNewest, BlogSortOldest, BlogSortPopular, BlogSortViews, BlogSortComments:
+	case BlogSortRelevance:
+		if p.Query == "" {
+			return fmt.Errorf("invalid sort %q without a search query", p.SortBy)
+		}
+	default:
+		return fmt.Errorf("invalid sort %q", p.SortBy)
+	}
+	if utf8.RuneCountInString(p.Query) > MaxSearchQueryLength {
+		return fmt.Errorf("invalid search query: at most %d characters", MaxSearchQueryLength)
+	}
+	if p.TagMatch != TagMatchAny && p.TagMatch != TagMatchAll {
+		return fmt.Errorf("invalid tag match %q, use any or all", p.TagMatch)
+	}
+	switch p.Status {
+	case BlogStatusPublished, BlogStatusDeleted, BlogStatusAll:
+	default:
+		return fmt.Errorf("invalid status %q", p.Status)
+	}
+	if len(p.Tags) > MaxListTags {
+		return fmt.Errorf("invalid tags: at most %d tags can be combined", MaxListTags)
+	}
+	if p.From != nil && p.To != nil && !p.From.Before(*p.To) {
+		return fmt.Errorf("invalid date range: from must be before to")
+	}
+	return nil
+}
 
 type PaginationResponse struct {
 	Data       interface{} `json:"data"`
@@ -147,4 +389,14 @@ type PaginationResponse struct {
 	Limit      int         `json:"limit"`
 	Total      int64       `json:"total"`
 	TotalPages int         `json:"total_pages"`
+	Facets     *BlogFacets `json:"facets,omitempty"` // only when asked for with ?facets=true
+}
+
+// page of a listing that is walked with an opaque cursor instead of page numbers
+type CursorPaginationResponse struct {
+	Data       interface{} `json:"data"`
+	Limit      int         `json:"limit"`
+	NextCursor string      `json:"next_cursor,omitempty"` // empty on the last page
+	PrevCursor string      `json:"prev_cursor,omitempty"` // empty on the first page
+	Total      *int64      `json:"total,omitempty"`       // only when asked for with ?count=true
 }
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// blog saved by a user, either to their private bookmarks or to one of their reading lists
+type Bookmark struct {
+	ID        primitive.ObjectID  `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID    primitive.ObjectID  `bson:"user_id" json:"user_id"`
+	ListID    *primitive.ObjectID `bson:"list_id" json:"list_id,omitempty"` // nil for private bookmarks
+	BlogID    primitive.ObjectID  `bson:"blog_id" json:"blog_id"`
+	Position  int64               `bson:"position" json:"-"`
+	CreatedAt time.Time           `bson:"created_at" json:"created_at"`
+	Blog      *Blog               `bson:"-" json:"blog,omitempty"`
+}
+
+// bookmarks are listed in the order their owner gave them; the sort their cursors belong to
+const BookmarkSortPosition = "position"
+
+// named, ordered collection of blogs that its owner can share
+type ReadingList struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	OwnerID       primitive.ObjectID `bson:"owner_id" json:"owner_id"`
+	OwnerUsername string             `bson:"owner_username" json:"owner_username"`
+	Name          string             `bson:"name" json:"name"`
+	Description   string             `bson:"description,omitempty" json:"description,omitempty"`
+	Public        bool               `bson:"public" json:"public"`
+	CreatedAt     time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// interface for bookmark data operations; listID nil addresses the private bookmarks
+type BookmarkRepository interface {
+	Add(bookmark *Bookmark) error
+	Remove(userID primitive.ObjectID, listID *primitive.ObjectID, blogID primitive.ObjectID) error
+	// lists in position order, with their blogs attached, leaving out bookmarks of blogs
+	// in the trash; pages with cursors like the blog listings do
+	List(userID primitive.ObjectID, listID *primitive.ObjectID, after *Cursor, limit int, count bool) ([]*Bookmark, *CursorPage, error)
+	ListBlogIDs(userID primitive.ObjectID, listID *primitive.ObjectID) ([]primitive.ObjectID, error)
+	// renumbers the positions to follow blogIDs
+	SetOrder(userID primitive.ObjectID, listID *primitive.ObjectID, blogIDs []primitive.ObjectID) error
+	// reports which of the blogs the user has saved anywhere
+	BookmarkedBlogIDs(userID primitive.ObjectID, blogIDs []primitive.ObjectID) (map[primitive.ObjectID]bool, error)
+	DeleteByList(listID primitive.ObjectID) error
+	DeleteByBlog(blogID primitive.ObjectID) error
+}
+
+// interface for reading list data operations
+type ReadingListRepository interface {
+	Create(list *ReadingList) error
+	GetByID(id primitive.ObjectID) (*ReadingList, error)
+	ListByOwner(ownerID primitive.ObjectID, publicOnly bool) ([]*ReadingList, error)
+	Update(list *ReadingList) error
+	Delete(id primitive.ObjectID) error
+}
+
+// interface for bookmark and reading list business logic
+type BookmarkUseCase interface {
+	AddBookmark(userID, blogID primitive.ObjectID) (*Bookmark, error)
+	RemoveBookmark(userID, blogID primitive.ObjectID) error
+	GetBookmarks(userID primitive.ObjectID, cursor string, limit int, count bool) ([]*Bookmark, *CursorPage, error)
+	ReorderBookmarks(userID primitive.ObjectID, blogIDs []primitive.ObjectID) error
+	CreateReadingList(userID primitive.ObjectID, req *CreateReadingListRequest) (*ReadingList, error)
+	GetReadingLists(username string, viewerID primitive.ObjectID) ([]*ReadingList, error)
+	GetReadingList(id, viewerID primitive.ObjectID, cursor string, limit int, count bool) (*ReadingList, []*Bookmark, *CursorPage, error)
+	UpdateReadingList(id, userID primitive.ObjectID, req *UpdateReadingListRequest) (*ReadingList, error)
+	DeleteReadingList(id, userID primitive.ObjectID) error
+	AddToReadingList(id, userID, blogID primitive.ObjectID) (*Bookmark, error)
+	RemoveFromReadingList(id, userID, blogID primitive.ObjectID) error
+	ReorderReadingList(id, userID primitive.ObjectID, blogIDs []primitive.ObjectID) error
+}
+
+type BookmarkRequest struct {
+	BlogID string `json:"blog_id" validate:"required,len=24,hexadecimal"`
+}
+
+type ReorderBookmarksRequest struct {
+	BlogIDs []string `json:"blog_ids" validate:"required,dive,len=24,hexadecimal"`
+}
+
+type CreateReadingListRequest struct {
+	Name        string `json:"name" validate:"required,min=1,max=100"`
+	Description string `json:"description" validate:"max=500"`
+	Public      bool   `json:"public"`
+}
+
+type UpdateReadingListRequest struct {
+	Name        *string `json:"name" validate:"omitempty,min=1,max=100"`
+	Description *string `json:"description" validate:"omitempty,max=500"`
+	Public      *bool   `json:"public"`
+}
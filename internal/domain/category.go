@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// node of the curated category tree, e.g. Engineering > Backend > Go
+type Category struct {
+	ID          primitive.ObjectID   `bson:"_id,omitempty" json:"id,omitempty"`
+	Name        string               `bson:"name" json:"name"`
+	Slug        string               `bson:"slug" json:"slug"`
+	Description string               `bson:"description,omitempty" json:"description,omitempty"`
+	ParentID    *primitive.ObjectID  `bson:"parent_id" json:"parent_id,omitempty"` // nil for top level categories
+	Ancestors   []primitive.ObjectID `bson:"ancestors" json:"-"`                   // root first, excluding the category itself
+	Position    int                  `bson:"position" json:"position"`             // order among its siblings
+	CreatedAt   time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time            `bson:"updated_at" json:"updated_at"`
+	Breadcrumbs []CategoryCrumb      `bson:"-" json:"breadcrumbs,omitempty"`
+	Children    []*Category          `bson:"-" json:"children,omitempty"`
+}
+
+// one step of the path from the root to a category
+type CategoryCrumb struct {
+	ID   primitive.ObjectID `json:"id"`
+	Name string             `json:"name"`
+	Slug string             `json:"slug"`
+}
+
+// interface for category data operations
+type CategoryRepository interface {
+	WithContext(ctx context.Context) CategoryRepository
+	Create(category *Category) error
+	GetByID(id primitive.ObjectID) (*Category, error)
+	GetBySlug(slug string) (*Category, error)
+	GetByIDs(ids []primitive.ObjectID) ([]*Category, error)
+	// every category, in sibling order
+	List() ([]*Category, error)
+	// every category below id, at any depth
+	ListDescendants(id primitive.ObjectID) ([]*Category, error)
+	HasChildren(id primitive.ObjectID) (bool, error)
+	Update(category *Category) error
+	SetAncestors(id primitive.ObjectID, ancestors []primitive.ObjectID) error
+	Delete(id primitive.ObjectID) error
+}
+
+// resolves the path to a category; implemented by the category use case
+type CategoryResolver interface {
+	// the categories from the root down to id, id included
+	Breadcrumbs(id primitive.ObjectID) ([]CategoryCrumb, error)
+}
+
+// interface for category business logic
+type CategoryUseCase interface {
+	CategoryResolver
+	GetCategoryTree() ([]*Category, error)
+	// looks the category up by ID or slug and lists its blogs, including those in subcategories
+	GetCategory(idOrSlug string, page, limit int) (*Category, []*Blog, int64, error)
+	CreateCategory(req *CreateCategoryRequest) (*Category, error)
+	UpdateCategory(id primitive.ObjectID, req *UpdateCategoryRequest) (*Category, error)
+	DeleteCategory(id primitive.ObjectID) error
+}
+
+type CreateCategoryRequest struct {
+	Name        string `json:"name" validate:"required,min=2,max=100"`
+	Description string `json:"description" validate:"max=500"`
+	ParentID    string `json:"parent_id" validate:"omitempty,len=24,hexadecimal"`
+	Position    int    `json:"position" validate:"min=0"`
+}
+
+type UpdateCategoryRequest struct {
+	Name        *string `json:"name" validate:"omitempty,min=2,max=100"`
+	Description *string `json:"description" validate:"omitempty,max=500"`
+	ParentID    *string `json:"parent_id" validate:"omitempty,len=0|len=24"` // empty string moves the category to the top level
+	Position    *int    `json:"position" validate:"omitempty,min=0"`
+}
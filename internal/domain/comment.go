@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// comment stored in its own collection; replies point at their parent
+type Comment struct {
+	ID             primitive.ObjectID   `bson:"_id,omitempty" json:"id,omitempty"`
+	BlogID         primitive.ObjectID   `bson:"blog_id" json:"blog_id"`
+	ParentID       *primitive.ObjectID  `bson:"parent_id" json:"parent_id,omitempty"` // nil for top level comments
+	Depth          int                  `bson:"depth" json:"depth"`
+	AuthorID       primitive.ObjectID   `bson:"author_id" json:"author_id"`
+	AuthorUsername string               `bson:"author_username" json:"author_username"`
+	Content        string               `bson:"content" json:"content" validate:"required,min=1"`
+	ContentHTML    string               `bson:"content_html" json:"content_html"` // escaped content with mentions linked
+	Mentions       []Mention            `bson:"mentions,omitempty" json:"mentions,omitempty"`
+	NotifiedUsers  []primitive.ObjectID `bson:"notified_users,omitempty" json:"-"` // mentioned users already notified, across edits
+	BlogAuthorID   primitive.ObjectID   `bson:"blog_author_id" json:"-"`
+	ReplyCount     int                  `bson:"reply_count" json:"reply_count"`
+	Status         string               `bson:"status" json:"status"`
+	SpamScore      float64              `bson:"spam_score" json:"spam_score"`
+	TrainedAs      string               `bson:"trained_as,omitempty" json:"-"` // spam label the classifier last learned from this comment
+	ModeratedBy    *primitive.ObjectID  `bson:"moderated_by,omitempty" json:"moderated_by,omitempty"`
+	ModeratedAt    *time.Time           `bson:"moderated_at,omitempty" json:"moderated_at,omitempty"`
+	Deleted        bool                 `bson:"deleted,omitempty" json:"deleted,omitempty"` // removed, but kept so its replies stay threaded
+	CreatedAt      time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time            `bson:"updated_at" json:"updated_at"`
+}
+
+// comments created before moderation existed have no status and count as approved
+func (c *Comment) IsApproved() bool {
+	return c.Status == "" || c.Status == CommentApproved
+}
+
+// sort orders for comment listings
+const (
+	CommentSortNewest = "newest"
+	CommentSortOldest = "oldest"
+	CommentSortTop    = "top"
+)
+
+// interface for comment data operations
+type CommentRepository interface {
+	WithContext(ctx context.Context) CommentRepository
+	Create(comment *Comment) error
+	GetByID(id primitive.ObjectID) (*Comment, error)
+	ListByBlog(blogID primitive.ObjectID, parentID *primitive.ObjectID, viewerID primitive.ObjectID, canModerate bool, sort string, page, limit int) ([]*Comment, int64, error)
+	ListByBlogCursor(blogID primitive.ObjectID, parentID *primitive.ObjectID, viewerID primitive.ObjectID, canModerate bool, sort string, after *Cursor, limit int, count bool) ([]*Comment, *CursorPage, error)
+	ListPending(blogAuthorID *primitive.ObjectID, page, limit int) ([]*Comment, int64, error)
+	SetStatus(id primitive.ObjectID, status string, moderatorID primitive.ObjectID) (*Comment, error)
+	HasApproved(authorID primitive.ObjectID) (bool, error)
+	SetTrainedAs(id primitive.ObjectID, label string) error
+	UpdateContent(id primitive.ObjectID, content, contentHTML string, mentions []Mention, notifiedUsers []primitive.ObjectID) error
+	Delete(id primitive.ObjectID) error
+	MarkDeleted(id primitive.ObjectID) error
+	IncrementReplyCount(id primitive.ObjectID, delta int) error
+	DeleteByBlog(blogID primitive.ObjectID) error
+}
+
+type CreateCommentRequest struct {
+	Content  string `json:"content" validate:"required,min=1,max=2000"`
+	ParentID string `json:"parent_id" validate:"omitempty,len=24,hexadecimal"`
+}
+
+type UpdateCommentRequest struct {
+	Content string `json:"content" validate:"required,min=1,max=2000"`
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// position in a listing ordered by a sort key with _id breaking ties. Unlike a page
+// number it stays put while items are added or removed in front of it.
+type Cursor struct {
+	Sort     string             // the listing's sort; a cursor only fits the sort it came from
+	Key      []interface{}      // sort field values of the item the page is next to, _id excluded
+	ID       primitive.ObjectID // _id of that item
+	Backward bool               // the page ends before the item rather than starting after it
+}
+
+// cursors reach clients as opaque strings: URL-safe base64 of the cursor as BSON, which
+// keeps the types of the key values, e.g. dates, intact
+func (c *Cursor) Encode() string {
+	raw, err := bson.Marshal(bson.M{"s": c.Sort, "k": c.Key, "i": c.ID, "b": c.Backward})
+	if err != nil {
+		// the key values come from decoded documents, so they always marshal
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// reads a cursor of the given sort as returned by Encode
+func ParseCursor(cursor, sort string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	var decoded struct {
+		Sort     string             `bson:"s"`
+		Key      []interface{}      `bson:"k"`
+		ID       primitive.ObjectID `bson:"i"`
+		Backward bool               `bson:"b"`
+	}
+	if err := bson.Unmarshal(raw, &decoded); err != nil || decoded.ID.IsZero() {
+		return nil, errors.New("invalid cursor")
+	}
+	if decoded.Sort != sort {
+		return nil, errors.New("invalid cursor: it belongs to a listing with a different sort")
+	}
+	return &Cursor{Sort: decoded.Sort, Key: decoded.Key, ID: decoded.ID, Backward: decoded.Backward}, nil
+}
+
+// where the pages around a page read by cursor start; empty where the listing ends
+type CursorPage struct {
+	Next  string
+	Prev  string
+	Total *int64 // only when counting was asked for
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		cursor Cursor
+	}{
+		{"date key", Cursor{Sort: "newest", Key: []interface{}{at}}},
+		{"count and date keys", Cursor{Sort: "popular", Key: []interface{}{int32(42), at}}},
+		{"score key read backward", Cursor{Sort: "relevance", Key: []interface{}{1.5}, Backward: true}},
+		{"no key", Cursor{Sort: "position", Key: []interface{}{}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cursor.ID = primitive.NewObjectID()
+			got, err := ParseCursor(tt.cursor.Encode(), tt.cursor.Sort)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Sort != tt.cursor.Sort || got.ID != tt.cursor.ID || got.Backward != tt.cursor.Backward {
+				t.Fatalf("got %+v, want %+v", got, tt.cursor)
+			}
+			if len(got.Key) != len(tt.cursor.Key) {
+				t.Fatalf("got key %v, want %v", got.Key, tt.cursor.Key)
+			}
+			// the key values keep their types, so they compare correctly against the fields again
+			for i, value := range tt.cursor.Key {
+				want := value
+				if date, ok := value.(time.Time); ok {
+					want = primitive.NewDateTimeFromTime(date)
+				}
+				if got.Key[i] != want {
+					t.Fatalf("key %d: got %#v, want %#v", i, got.Key[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseCursorRejects(t *testing.T) {
+	valid := (&Cursor{Sort: "newest", Key: []interface{}{1}, ID: primitive.NewObjectID()}).Encode()
+	noID, _ := bson.Marshal(bson.M{"s": "newest", "k": bson.A{1}})
+
+	tests := []struct {
+		name   string
+		cursor string
+		sort   string
+	}{
+		{"not base64", "%%%", "newest"},
+		{"not bson", base64.RawURLEncoding.EncodeToString([]byte("position:1")), "newest"},
+		{"missing id", base64.RawURLEncoding.EncodeToString(noID), "newest"},
+		{"other sort", valid, "oldest"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCursor(tt.cursor, tt.sort); err == nil {
+				t.Fatal("expected the cursor to be rejected")
+			}
+		})
+	}
+}
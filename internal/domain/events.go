@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// names of the domain events recorded in the outbox
+const (
+	EventBlogCreated      = "blog.created"
+	EventBlogUpdated      = "blog.updated"
+	EventBlogDeleted      = "blog.deleted"
+	EventBlogRestored     = "blog.restored"
+	EventBlogsChanged     = "blogs.changed"
+	EventCommentAdded     = "comment.added"
+	EventCommentModerated = "comment.moderated"
+	EventReactionChanged  = "reaction.changed"
+	EventUserRegistered   = "user.registered"
+)
+
+// something that happened in the domain, recorded together with the state change that caused it
+type DomainEvent interface {
+	EventName() string
+}
+
+type BlogCreated struct {
+	Blog *Blog `json:"blog"`
+}
+
+type BlogUpdated struct {
+	Blog *Blog `json:"blog"`
+}
+
+type BlogDeleted struct {
+	Blog *Blog `json:"blog"`
+}
+
+type BlogRestored struct {
+	Blog *Blog `json:"blog"`
+}
+
+// blogs rewritten in bulk, e.g. by a tag rename or merge; subscribers read the blogs
+// back as the event only carries their IDs
+type BlogsChanged struct {
+	IDs []primitive.ObjectID `json:"ids"`
+}
+
+type CommentAdded struct {
+	Comment      *Comment           `json:"comment"`
+	BlogAuthorID primitive.ObjectID `json:"blog_author_id"`
+}
+
+type CommentModerated struct {
+	Comment        *Comment           `json:"comment"` // with its new status
+	BlogAuthorID   primitive.ObjectID `json:"blog_author_id"`
+	PreviousStatus string             `json:"previous_status"`
+	ModeratorID    primitive.ObjectID `json:"moderator_id"`
+}
+
+type ReactionChanged struct {
+	Counts       ReactionCounts     `json:"counts"`
+	BlogAuthorID primitive.ObjectID `json:"blog_author_id"`
+	UserID       primitive.ObjectID `json:"user_id"`
+}
+
+type UserRegistered struct {
+	UserID   primitive.ObjectID `json:"user_id"`
+	Username string             `json:"username"`
+	Email    string             `json:"email"`
+}
+
+func (*BlogCreated) EventName() string      { return EventBlogCreated }
+func (*BlogUpdated) EventName() string      { return EventBlogUpdated }
+func (*BlogDeleted) EventName() string      { return EventBlogDeleted }
+func (*BlogRestored) EventName() string     { return EventBlogRestored }
+func (*BlogsChanged) EventName() string     { return EventBlogsChanged }
+func (*CommentAdded) EventName() string     { return EventCommentAdded }
+func (*CommentModerated) EventName() string { return EventCommentModerated }
+func (*ReactionChanged) EventName() string  { return EventReactionChanged }
+func (*UserRegistered) EventName() string   { return EventUserRegistered }
+
+// returns an empty event of the named type to decode an outbox message into
+func NewDomainEvent(name string) (DomainEvent, bool) {
+	switch name {
+	case EventBlogCreated:
+		return &BlogCreated{}, true
+	case EventBlogUpdated:
+		return &BlogUpdated{}, true
+	case EventBlogDeleted:
+		return &BlogDeleted{}, true
+	case EventBlogRestored:
+		return &BlogRestored{}, true
+	case EventBlogsChanged:
+		return &BlogsChanged{}, true
+	case EventCommentAdded:
+		return &CommentAdded{}, true
+	case EventCommentModerated:
+		return &CommentModerated{}, true
+	case EventReactionChanged:
+		return &ReactionChanged{}, true
+	case EventUserRegistered:
+		return &UserRegistered{}, true
+	}
+	return nil, false
+}
+
+// outbox message states
+const (
+	OutboxPending    = "pending"
+	OutboxDispatched = "dispatched"
+	OutboxFailed     = "failed" // retries exhausted
+)
+
+// domain event waiting in the outbox to be handed to subscribers
+type OutboxMessage struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Name          string             `bson:"name" json:"name"`
+	Payload       string             `bson:"payload" json:"payload"` // JSON encoded event
+	Status        string             `bson:"status" json:"status"`
+	Attempts      int                `bson:"attempts" json:"attempts"`
+	Handled       []string           `bson:"handled,omitempty" json:"handled,omitempty"` // subscribers that already succeeded
+	LastError     string             `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	NextAttemptAt time.Time          `bson:"next_attempt_at" json:"next_attempt_at"`
+	OccurredAt    time.Time          `bson:"occurred_at" json:"occurred_at"`
+	DispatchedAt  *time.Time         `bson:"dispatched_at,omitempty" json:"dispatched_at,omitempty"`
+}
+
+// interface for the transactional outbox
+type OutboxRepository interface {
+	WithContext(ctx context.Context) OutboxRepository
+	Append(events ...DomainEvent) error
+	// leases the oldest due message so that concurrent dispatchers do not handle it twice
+	ClaimPending(now time.Time, lease time.Duration) (*OutboxMessage, error)
+	Update(message *OutboxMessage) error
+}
+
+// runs fn in a database transaction; repositories bound to ctx with WithContext take part in it
+type Transactor interface {
+	WithTransaction(fn func(ctx context.Context) error) error
+}
+
+// handles one type of domain event; returning an error makes the outbox retry it. The
+// event ID is the outbox message's and stays the same across retries, so handlers can
+// use it to make their effects idempotent.
+type EventHandler func(eventID primitive.ObjectID, event DomainEvent) error
+
+// in-process event bus fed by the outbox dispatcher. Every subscriber is named so that
+// a retried message is only redelivered to the subscribers that failed.
+type EventBus interface {
+	Subscribe(eventName, subscriber string, handler EventHandler)
+	Subscribers(eventName string) map[string]EventHandler
+}
@@ -79,6 +79,20 @@ type PasswordService interface {
 	GenerateSecureToken(length int) string
 }
 
+// result of rendering markdown content
+type RenderedContent struct {
+	HTML        string
+	Excerpt     string
+	WordCount   int
+	ReadingTime int
+	TOC         []TOCEntry
+}
+
+// interface for rendering blog content to sanitized HTML
+type ContentRenderer interface {
+	Render(source string) (*RenderedContent, error)
+}
+
 // type for context keys
 type ContextKey string
 
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// comment moderation modes, set site-wide or per blog
+const (
+	CommentModeOpen         = "open"
+	CommentModePreModerated = "pre_moderated"
+	CommentModeFirstTime    = "first_time" // only first-time commenters are moderated
+	CommentModeClosed       = "closed"
+)
+
+// comment moderation states
+const (
+	CommentApproved = "approved"
+	CommentPending  = "pending"
+	CommentRejected = "rejected"
+	CommentSpam     = "spam"
+)
+
+// moderation actions
+const (
+	ModerationApprove = "approve"
+	ModerationReject  = "reject"
+	ModerationSpam    = "spam"
+)
+
+const SettingCommentMode = "comment_mode"
+
+func IsValidCommentMode(mode string) bool {
+	switch mode {
+	case CommentModeOpen, CommentModePreModerated, CommentModeFirstTime, CommentModeClosed:
+		return true
+	}
+	return false
+}
+
+// site-wide setting stored in the settings collection
+type Setting struct {
+	Key       string    `bson:"_id" json:"key"`
+	Value     string    `bson:"value" json:"value"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// interface for site-wide settings
+type SettingsRepository interface {
+	Get(key string) (string, error)
+	Set(key, value string) error
+}
+
+// decides which moderation state a new comment starts in
+type CommentPolicy interface {
+	Evaluate(blog *Blog, comment *Comment, author *User) (string, error)
+	SiteMode() string
+}
+
+// interface for comment moderation business logic
+type ModerationUseCase interface {
+	GetQueue(userID primitive.ObjectID, userRole string, page, limit int) ([]*Comment, int64, error)
+	ModerateComment(commentID primitive.ObjectID, action string, userID primitive.ObjectID, userRole string) (*Comment, error)
+	GetSiteCommentMode() (string, error)
+	SetSiteCommentMode(mode string) error
+	SetBlogCommentMode(blogID primitive.ObjectID, mode string, userID primitive.ObjectID, userRole string) error
+}
+
+// reports whether a role may moderate comments on any blog
+func IsModeratorRole(role string) bool {
+	return role == RoleAdmin || role == RoleModerator
+}
+
+type CommentModeRequest struct {
+	Mode string `json:"mode" validate:"required,oneof=open pre_moderated first_time closed"`
+}
+
+// per-post mode; "inherit" falls back to the site-wide mode
+type BlogCommentModeRequest struct {
+	Mode string `json:"mode" validate:"required,oneof=inherit open pre_moderated first_time closed"`
+}
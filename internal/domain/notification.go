@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// notification types
+const (
+	NotificationComment    = "comment" // someone commented on my post
+	NotificationReply      = "reply"   // someone replied to my comment
+	NotificationLike       = "like"
+	NotificationMention    = "mention"
+	NotificationModeration = "moderation" // a moderator approved, rejected or flagged my comment
+)
+
+// every notification type, used for preferences
+var NotificationTypes = []string{
+	NotificationComment,
+	NotificationReply,
+	NotificationLike,
+	NotificationMention,
+	NotificationModeration,
+}
+
+func IsValidNotificationType(t string) bool {
+	for _, known := range NotificationTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// message shown to a single user about activity involving them.
+// Notifications sharing a GroupKey collapse into one while unread, e.g. "5 people liked your post".
+type Notification struct {
+	ID            primitive.ObjectID   `bson:"_id,omitempty" json:"id,omitempty"`
+	RecipientID   primitive.ObjectID   `bson:"recipient_id" json:"recipient_id"`
+	Type          string               `bson:"type" json:"type"`
+	ActorID       primitive.ObjectID   `bson:"actor_id" json:"actor_id"` // most recent actor
+	ActorUsername string               `bson:"actor_username" json:"actor_username"`
+	ActorIDs      []primitive.ObjectID `bson:"actor_ids" json:"-"`
+	ActorCount    int                  `bson:"-" json:"actor_count"`
+	BlogID        primitive.ObjectID   `bson:"blog_id,omitempty" json:"blog_id,omitempty"`
+	CommentID     *primitive.ObjectID  `bson:"comment_id,omitempty" json:"comment_id,omitempty"`
+	Status        string               `bson:"status,omitempty" json:"status,omitempty"` // outcome of a moderation notification
+	GroupKey      string               `bson:"group_key,omitempty" json:"-"`
+	Message       string               `bson:"-" json:"message"`
+	Read          bool                 `bson:"read" json:"read"`
+	ReadAt        *time.Time           `bson:"read_at,omitempty" json:"read_at,omitempty"`
+	CreatedAt     time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time            `bson:"updated_at" json:"updated_at"`
+}
+
+// interface for notification data operations
+type NotificationRepository interface {
+	Create(notification *Notification) error
+	Group(notification *Notification) error
+	List(recipientID primitive.ObjectID, unreadOnly bool, page, limit int) ([]*Notification, int64, error)
+	CountUnread(recipientID primitive.ObjectID) (int64, error)
+	MarkRead(id, recipientID primitive.ObjectID) error
+	MarkAllRead(recipientID primitive.ObjectID) (int64, error)
+}
+
+// delivers notifications on behalf of other use cases
+type Notifier interface {
+	Notify(notification *Notification)
+}
+
+// interface for the notification center
+type NotificationUseCase interface {
+	Notifier
+	GetNotifications(userID primitive.ObjectID, unreadOnly bool, page, limit int) ([]*Notification, int64, error)
+	GetUnreadCount(userID primitive.ObjectID) (int64, error)
+	MarkRead(id, userID primitive.ObjectID) error
+	MarkAllRead(userID primitive.ObjectID) (int64, error)
+	GetPreferences(userID primitive.ObjectID) (map[string]bool, error)
+	UpdatePreferences(userID primitive.ObjectID, preferences map[string]bool) (map[string]bool, error)
+}
+
+// user referenced with @username in a blog or comment
+type Mention struct {
+	UserID   primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Username string             `bson:"username" json:"username"`
+}
+
+// enables or disables notification types, keyed by type
+type NotificationPreferencesRequest struct {
+	Preferences map[string]bool `json:"preferences" validate:"required"`
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// query for the search index; the text is matched with typo tolerance and its last
+// word as a prefix, the filters narrow the matches down
+type SearchQuery struct {
+	Query  string   // "exact phrases" and -excluded words are understood too
+	Tags   []string // blogs carrying any of them
+	Author string   // author username
+	Year   int      // year the blog was written in, 0 for any
+	Page   int
+	Limit  int
+}
+
+// one blog found by the search index
+type SearchHit struct {
+	ID        primitive.ObjectID
+	Score     float64
+	Fragments map[string][]string // highlighted excerpts by field, "title" and "content"
+}
+
+// number of matching blogs sharing a tag, author or year
+type FacetCount struct {
+	Term  string `json:"term"`
+	Count int    `json:"count"`
+}
+
+type SearchFacets struct {
+	Tags    []FacetCount `json:"tags"`
+	Authors []FacetCount `json:"authors"`
+	Years   []FacetCount `json:"years"`
+}
+
+type SearchResults struct {
+	Hits   []SearchHit
+	Total  int64
+	Facets SearchFacets
+}
+
+// full-text index of the blogs outside the trash, kept next to the database for the
+// searches Mongo's $text cannot do
+type SearchIndex interface {
+	// adds the blogs or replaces their entries
+	Index(blogs ...*Blog) error
+	Remove(id primitive.ObjectID) error
+	Search(query *SearchQuery) (*SearchResults, error)
+	// number of indexed blogs
+	Count() (uint64, error)
+	Close() error
+}
+
+// interface for search business logic
+type SearchUseCase interface {
+	// ranked blogs with highlighted matches, and facet counts over all matches
+	Search(query *SearchQuery) ([]*Blog, int64, *SearchFacets, error)
+	// brings the index entry of a blog in line with the database
+	Sync(blogID primitive.ObjectID) error
+	// indexes every blog outside the trash and returns how many there were
+	Reindex() (int, error)
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ordered collection of blogs published as a multi-part series
+type Series struct {
+	ID            primitive.ObjectID   `bson:"_id,omitempty" json:"id,omitempty"`
+	OwnerID       primitive.ObjectID   `bson:"owner_id" json:"owner_id"`
+	OwnerUsername string               `bson:"owner_username" json:"owner_username"`
+	Title         string               `bson:"title" json:"title"`
+	Description   string               `bson:"description,omitempty" json:"description,omitempty"`
+	BlogIDs       []primitive.ObjectID `bson:"blog_ids" json:"blog_ids"`
+	CreatedAt     time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time            `bson:"updated_at" json:"updated_at"`
+}
+
+// previous/next navigation for a blog that is part of a series
+type SeriesNavigation struct {
+	SeriesID primitive.ObjectID `json:"series_id"`
+	Title    string             `json:"title"`
+	Position int                `json:"position"` // 1-based
+	Total    int                `json:"total"`
+	Previous *SeriesNavItem     `json:"previous,omitempty"`
+	Next     *SeriesNavItem     `json:"next,omitempty"`
+}
+
+type SeriesNavItem struct {
+	ID    primitive.ObjectID `json:"id"`
+	Title string             `json:"title"`
+	Slug  string             `json:"slug"`
+}
+
+// interface for series data operations
+type SeriesRepository interface {
+	Create(series *Series) error
+	GetByID(id primitive.ObjectID) (*Series, error)
+	GetByBlogID(blogID primitive.ObjectID) (*Series, error)
+	List(page, limit int) ([]*Series, int64, error)
+	Update(series *Series) error
+	Delete(id primitive.ObjectID) error
+	RemoveBlog(blogID primitive.ObjectID) error
+}
+
+type CreateSeriesRequest struct {
+	Title       string   `json:"title" validate:"required,min=3,max=200"`
+	Description string   `json:"description" validate:"max=1000"`
+	BlogIDs     []string `json:"blog_ids" validate:"omitempty,dive,len=24,hexadecimal"`
+}
+
+type UpdateSeriesRequest struct {
+	Title       *string `json:"title" validate:"omitempty,min=3,max=200"`
+	Description *string `json:"description" validate:"omitempty,max=1000"`
+}
+
+type ReorderSeriesRequest struct {
+	BlogIDs []string `json:"blog_ids" validate:"required,dive,len=24,hexadecimal"`
+}
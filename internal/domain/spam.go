@@ -0,0 +1,37 @@
+package domain
+
+// labels used when training the spam classifier
+const (
+	SpamLabelSpam = "spam"
+	SpamLabelHam  = "ham"
+)
+
+// breakdown of how a comment was scored
+type SpamVerdict struct {
+	Score          float64 `json:"score"` // 0 (clean) to 1 (spam)
+	BayesScore     float64 `json:"bayes_score"`
+	LinkCount      int     `json:"link_count"`
+	BlockedDomain  string  `json:"blocked_domain,omitempty"`
+	AccountAgeDays float64 `json:"account_age_days"`
+}
+
+// interface for scoring comments and learning from moderator decisions
+type SpamClassifier interface {
+	Classify(content string, author *User) (*SpamVerdict, error)
+	Train(content, label string) error
+	Untrain(content, label string) error
+}
+
+// per-token counts of the naive Bayes model
+type SpamTokenStats struct {
+	Token string `bson:"_id"`
+	Spam  int    `bson:"spam"`
+	Ham   int    `bson:"ham"`
+}
+
+// interface for persisting the spam model
+type SpamModelRepository interface {
+	GetDocumentCounts() (spam, ham int, err error)
+	GetTokenStats(tokens []string) (map[string]SpamTokenStats, error)
+	AddSamples(tokens []string, label string, delta int) error
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// event types pushed to real-time clients
+const (
+	EventNotification = "notification"
+	EventComment      = "comment"
+	EventReactions    = "reactions"
+)
+
+// message published on a topic, already encoded so every subscriber sees the same payload
+type Event struct {
+	ID        uint64
+	Topic     string
+	Type      string
+	Data      []byte // JSON
+	CreatedAt time.Time
+}
+
+// live reaction counts of a blog, by reaction type
+type ReactionCounts struct {
+	BlogID primitive.ObjectID `json:"blog_id"`
+	Counts map[string]int     `json:"counts"`
+}
+
+// topic carrying events for a single user, such as their notifications
+func UserTopic(userID primitive.ObjectID) string {
+	return "user:" + userID.Hex()
+}
+
+// topic carrying public activity on a blog, such as new comments and reaction counts
+func BlogTopic(blogID primitive.ObjectID) string {
+	return "blog:" + blogID.Hex()
+}
+
+// publishes events; implementations must not block the caller
+type EventPublisher interface {
+	Publish(topic, eventType string, data interface{})
+}
+
+// pub/sub hub behind the real-time stream. The in-process hub can be replaced by a
+// distributed broker as long as event IDs stay increasing.
+type PubSub interface {
+	EventPublisher
+	// subscribes to the topics, first replaying retained events newer than lastEventID.
+	// The channel is closed when cancel is called or the subscriber falls too far behind.
+	Subscribe(topics []string, lastEventID uint64) (events <-chan Event, cancel func())
+}
@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// registered tag; blogs refer to tags by their normalized name
+type Tag struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Name        string             `bson:"name" json:"name"`
+	Description string             `bson:"description,omitempty" json:"description,omitempty"`
+	UsageCount  int64              `bson:"usage_count" json:"usage_count"` // blogs outside the trash using the tag
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+	Synonyms    []string           `bson:"-" json:"synonyms,omitempty"`
+}
+
+// alternative name that is replaced by its tag whenever a blog is saved, e.g. "golang" for "go"
+type TagSynonym struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Name      string             `bson:"name" json:"name"`
+	Tag       string             `bson:"tag" json:"tag"` // name of the canonical tag
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+var (
+	tagNamePattern   = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	tagSeparatorRuns = regexp.MustCompile(`[\s_-]+`)
+)
+
+// lowercases the name and turns runs of spaces, underscores and hyphens into a single hyphen,
+// e.g. "Machine_Learning" becomes "machine-learning"
+func NormalizeTagName(name string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	normalized = strings.Trim(tagSeparatorRuns.ReplaceAllString(normalized, "-"), "-")
+	if len(normalized) < 2 || len(normalized) > 20 || !tagNamePattern.MatchString(normalized) {
+		return "", fmt.Errorf("invalid tag %q: use 2 to 20 letters, digits and hyphens", name)
+	}
+	return normalized, nil
+}
+
+// normalizes what was typed so far the way NormalizeTagName would, without requiring a complete, valid name
+func NormalizeTagPrefix(query string) string {
+	prefix := strings.ToLower(strings.TrimSpace(query))
+	return strings.TrimLeft(tagSeparatorRuns.ReplaceAllString(prefix, "-"), "-")
+}
+
+// normalizes the tags of a blog and drops duplicates, keeping the order they were given in
+func NormalizeTags(names []string) ([]string, error) {
+	tags := make([]string, 0, len(names))
+	seen := map[string]bool{}
+	for _, name := range names {
+		tag, err := NormalizeTagName(name)
+		if err != nil {
+			return nil, err
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// interface for tag data operations
+type TagRepository interface {
+	WithContext(ctx context.Context) TagRepository
+	Create(tag *Tag) error
+	GetByName(name string) (*Tag, error)
+	GetByNames(names []string) ([]*Tag, error)
+	// most used first
+	ListPopular(page, limit int) ([]*Tag, int64, error)
+	// tags starting with prefix, most used first
+	SearchPrefix(prefix string, limit int) ([]*Tag, error)
+	Update(tag *Tag) error
+	Delete(id primitive.ObjectID) error
+	// adjusts usage counts by name, registering tags the first time they are used
+	IncrementUsage(deltas map[string]int) error
+	SetUsage(name string, count int64) error
+	// replaces every usage count with counts, registering missing tags; tags not in counts drop to zero
+	ResetUsage(counts map[string]int64) error
+}
+
+// interface for tag synonym data operations
+type TagSynonymRepository interface {
+	WithContext(ctx context.Context) TagSynonymRepository
+	Create(synonym *TagSynonym) error
+	Delete(name string) error
+	ListByTags(tags []string) ([]*TagSynonym, error)
+	// maps those of names that are synonyms to their tag
+	Resolve(names []string) (map[string]string, error)
+	SearchPrefix(prefix string, limit int) ([]*TagSynonym, error)
+	// points the synonyms of one tag at another, used when tags are renamed or merged
+	Retarget(from, to string) error
+}
+
+// maps tag names to the tags blogs are saved with
+type TagCanonicalizer interface {
+	// normalizes the names, replaces synonyms with their tag and drops duplicates
+	Canonicalize(names []string) ([]string, error)
+	// the canonical tags plus all of their synonyms, so filters also match blogs saved before a synonym was added
+	Expand(names []string) ([]string, error)
+}
+
+// interface for tag business logic
+type TagUseCase interface {
+	TagCanonicalizer
+	GetPopularTags(page, limit int) ([]*Tag, int64, error)
+	GetTag(name string, page, limit int) (*Tag, []*Blog, int64, error)
+	CreateTag(req *CreateTagRequest) (*Tag, error)
+	// renames the tag on every blog when the name changes
+	UpdateTag(name string, req *UpdateTagRequest) (*Tag, error)
+	// folds the tag into another one, rewriting the blogs that use it
+	MergeTags(name, into string) (*Tag, error)
+	// recomputes usage counts from the blogs, repairing any drift
+	RecountUsage() error
+	// tags matching what was typed so far, by prefix and then by similarity, most used first
+	Autocomplete(query string, limit int) ([]*Tag, error)
+	// known tags that the draft talks about, best match first
+	SuggestTags(req *SuggestTagsRequest, limit int) ([]*Tag, error)
+	AddSynonym(name, synonym string) (*TagSynonym, error)
+	RemoveSynonym(name, synonym string) error
+}
+
+type CreateTagRequest struct {
+	Name        string `json:"name" validate:"required,min=2,max=20"`
+	Description string `json:"description" validate:"max=500"`
+}
+
+type UpdateTagRequest struct {
+	Name        *string `json:"name" validate:"omitempty,min=2,max=20"`
+	Description *string `json:"description" validate:"omitempty,max=500"`
+}
+
+type TagSynonymRequest struct {
+	Synonym string `json:"synonym" validate:"required,min=2,max=20"`
+}
+
+type SuggestTagsRequest struct {
+	Title   string `json:"title" validate:"max=255"`
+	Content string `json:"content" validate:"required"`
+}
+
+type MergeTagRequest struct {
+	Into string `json:"into" validate:"required,min=2,max=20"`
+}
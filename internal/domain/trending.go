@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// periods a trending ranking covers, by when the blogs were written
+const (
+	TrendingToday   = "today" // the last 24 hours
+	TrendingWeek    = "week"  // the last 7 days
+	TrendingAllTime = "all"
+)
+
+// how much each kind of engagement counts towards a trending score, and how fast the
+// score fades: it halves every HalfLife, like the "hot" rankings of link aggregators
+type TrendingWeights struct {
+	Views    float64
+	Likes    float64
+	Dislikes float64 // subtracted
+	Comments float64
+	HalfLife time.Duration // 0 turns the decay off
+}
+
+type TrendingEntry struct {
+	BlogID primitive.ObjectID `bson:"blog_id" json:"blog_id"`
+	Score  float64            `bson:"score" json:"score"`
+}
+
+// the best scoring blogs of a window as of the last recomputation, best first
+type TrendingRanking struct {
+	Window     string          `bson:"_id" json:"window"`
+	Blogs      []TrendingEntry `bson:"blogs" json:"blogs"`
+	ComputedAt time.Time       `bson:"computed_at" json:"computed_at"`
+}
+
+// interface for the stored rankings
+type TrendingRepository interface {
+	// replaces the ranking of its window
+	Save(ranking *TrendingRanking) error
+	Get(window string) (*TrendingRanking, error)
+}
+
+// read side of the trending rankings, as needed by listings
+type TrendingRanker interface {
+	// the ranking of a window cut to limit blogs; empty until it was first computed
+	Ranking(window string, limit int) (*TrendingRanking, error)
+}
+
+// interface for trending business logic
+type TrendingUseCase interface {
+	TrendingRanker
+	// recomputes the ranking of every window from the blogs' current counters
+	Refresh() error
+}
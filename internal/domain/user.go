@@ -1,27 +1,32 @@
 package domain
 
 import (
+	"context"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type User struct {
-	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
-	Username       string             `bson:"username" json:"username" validate:"required,min=3,max=50"`
-	Email          string             `bson:"email" json:"email" validate:"required,email"`
-	Password       string             `bson:"password" json:"-" validate:"required,min=6"` // "-" means don't include in JSON
-	Role           string             `bson:"role" json:"role"`
-	ProfilePicture *Photo             `bson:"profile_picture,omitempty" json:"profile_picture,omitempty"`
-	Bio            string             `bson:"bio,omitempty" json:"bio,omitempty"`
-	CreatedAt      time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt      time.Time          `bson:"updated_at" json:"updated_at"`
+	ID             primitive.ObjectID   `bson:"_id,omitempty" json:"id,omitempty"`
+	Username       string               `bson:"username" json:"username" validate:"required,min=3,max=50"`
+	Email          string               `bson:"email" json:"email" validate:"required,email"`
+	Password       string               `bson:"password" json:"-" validate:"required,min=6"` // "-" means don't include in JSON
+	Role           string               `bson:"role" json:"role"`
+	ProfilePicture *Photo               `bson:"profile_picture,omitempty" json:"profile_picture,omitempty"`
+	Bio            string               `bson:"bio,omitempty" json:"bio,omitempty"`
+	BlockedUsers   []primitive.ObjectID `bson:"blocked_users,omitempty" json:"blocked_users,omitempty"`
+	// notification types the user turned off; types missing from the map are enabled
+	NotificationPreferences map[string]bool `bson:"notification_preferences,omitempty" json:"-"`
+	CreatedAt               time.Time       `bson:"created_at" json:"created_at"`
+	UpdatedAt               time.Time       `bson:"updated_at" json:"updated_at"`
 }
 
 // Constants for user roles to avoid magic strings.
 const (
-	RoleAdmin = "admin"
-	RoleUser  = "user"
+	RoleAdmin     = "admin"
+	RoleModerator = "moderator"
+	RoleUser      = "user"
 )
 
 type Photo struct {
@@ -32,6 +37,7 @@ type Photo struct {
 }
 
 type UserRepository interface {
+	WithContext(ctx context.Context) UserRepository
 	Create(user *User) error
 	GetByID(id primitive.ObjectID) (*User, error)
 	GetByEmail(email string) (*User, error)
@@ -42,12 +48,16 @@ type UserRepository interface {
 	UpdatePassword(id primitive.ObjectID, password string) error
 	UpdateRole(id primitive.ObjectID, role string) error
 	UploadProfilePicture(id primitive.ObjectID, photo *Photo) error
+	BlockUser(id, blockedID primitive.ObjectID) error
+	UnblockUser(id, blockedID primitive.ObjectID) error
+	UpdateNotificationPreferences(id primitive.ObjectID, preferences map[string]bool) error
 }
 
 type UserUseCase interface {
 	Register(username, email, password string) (*User, error)
 	Login(email, password string) (*LoginResponse, error)
 	GetByID(id primitive.ObjectID) (*User, error)
+	GetPublicProfile(username string) (*PublicProfile, error)
 	UpdateProfile(id primitive.ObjectID, bio, profilePic, contactInfo *string) (*User, error)
 	UpdateRole(id primitive.ObjectID, role string) error
 	ValidatePassword(password string) error
@@ -55,6 +65,27 @@ type UserUseCase interface {
 	CheckPassword(password, hash string) bool
 	RefreshToken(refreshToken string) (*LoginResponse, error)
 	Logout(userID primitive.ObjectID) error
+	BlockUser(userID primitive.ObjectID, username string) error
+	UnblockUser(userID primitive.ObjectID, username string) error
+}
+
+// the parts of a user that anyone may see, served at /@username
+type PublicProfile struct {
+	ID             primitive.ObjectID `json:"id"`
+	Username       string             `json:"username"`
+	Bio            string             `json:"bio,omitempty"`
+	ProfilePicture *Photo             `json:"profile_picture,omitempty"`
+	CreatedAt      time.Time          `json:"created_at"`
+}
+
+// reports whether the user has blocked the other user
+func (u *User) HasBlocked(userID primitive.ObjectID) bool {
+	for _, id := range u.BlockedUsers {
+		if id == userID {
+			return true
+		}
+	}
+	return false
 }
 
 type RegisterRequest struct {
@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// events that can be delivered to webhooks
+const (
+	WebhookBlogCreated     = "blog.created"
+	WebhookBlogPublished   = "blog.published" // posts go live on creation and again when restored from the trash
+	WebhookBlogUpdated     = "blog.updated"
+	WebhookBlogDeleted     = "blog.deleted"
+	WebhookCommentCreated  = "comment.created"
+	WebhookReactionChanged = "reaction.changed"
+)
+
+var WebhookEvents = []string{
+	WebhookBlogCreated,
+	WebhookBlogPublished,
+	WebhookBlogUpdated,
+	WebhookBlogDeleted,
+	WebhookCommentCreated,
+	WebhookReactionChanged,
+}
+
+// webhook delivery states
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed" // retries exhausted
+)
+
+// endpoint that receives signed event payloads. A user's webhook receives events about their
+// own posts; global webhooks, which only admins can create, receive events for every post.
+type Webhook struct {
+	ID                  primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	OwnerID             primitive.ObjectID `bson:"owner_id" json:"owner_id"`
+	URL                 string             `bson:"url" json:"url"`
+	Secret              string             `bson:"secret" json:"secret,omitempty"` // only returned when the webhook is created
+	Events              []string           `bson:"events" json:"events"`
+	Global              bool               `bson:"global" json:"global"`
+	Active              bool               `bson:"active" json:"active"`
+	ConsecutiveFailures int                `bson:"consecutive_failures" json:"consecutive_failures"`
+	DisabledAt          *time.Time         `bson:"disabled_at" json:"disabled_at,omitempty"` // set when disabled for failing
+	CreatedAt           time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt           time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// one event sent to one webhook, with the outcome of its latest attempt
+type WebhookDelivery struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	WebhookID      primitive.ObjectID `bson:"webhook_id" json:"webhook_id"`
+	EventID        primitive.ObjectID `bson:"event_id,omitempty" json:"event_id,omitempty"` // id of the payload; unset on redeliveries
+	Event          string             `bson:"event" json:"event"`
+	Payload        string             `bson:"payload" json:"payload"`
+	Status         string             `bson:"status" json:"status"`
+	Attempts       int                `bson:"attempts" json:"attempts"`
+	ResponseStatus int                `bson:"response_status,omitempty" json:"response_status,omitempty"`
+	Error          string             `bson:"error,omitempty" json:"error,omitempty"`
+	NextAttemptAt  time.Time          `bson:"next_attempt_at" json:"next_attempt_at"`
+	DeliveredAt    *time.Time         `bson:"delivered_at,omitempty" json:"delivered_at,omitempty"`
+	CreatedAt      time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// interface for webhook data operations
+type WebhookRepository interface {
+	Create(webhook *Webhook) error
+	GetByID(id primitive.ObjectID) (*Webhook, error)
+	ListByOwner(ownerID primitive.ObjectID) ([]*Webhook, error)
+	FindSubscribed(event string, ownerID primitive.ObjectID) ([]*Webhook, error)
+	Update(webhook *Webhook) error
+	Delete(id primitive.ObjectID) error
+	RecordFailure(id primitive.ObjectID) (int, error)
+	ResetFailures(id primitive.ObjectID) error
+	Disable(id primitive.ObjectID) error
+}
+
+// interface for webhook delivery data operations
+type WebhookDeliveryRepository interface {
+	Create(delivery *WebhookDelivery) error
+	// creates the delivery unless the webhook already has one for its event id
+	CreateOnce(delivery *WebhookDelivery) error
+	GetByID(id primitive.ObjectID) (*WebhookDelivery, error)
+	ListByWebhook(webhookID primitive.ObjectID, page, limit int) ([]*WebhookDelivery, int64, error)
+	// leases the next due delivery so that concurrent workers do not send it twice
+	ClaimDue(now time.Time, lease time.Duration) (*WebhookDelivery, error)
+	Update(delivery *WebhookDelivery) error
+	DeleteByWebhook(webhookID primitive.ObjectID) error
+}
+
+// sends a signed payload and reports the HTTP status of the response
+type WebhookSender interface {
+	Send(url, secret, event string, deliveryID primitive.ObjectID, payload []byte) (int, error)
+}
+
+// queues events for the webhooks subscribed to them
+type WebhookDispatcher interface {
+	// queues at most one delivery per webhook for each domain event and webhook event,
+	// however often it is called with the same eventID
+	Dispatch(eventID primitive.ObjectID, event string, ownerID primitive.ObjectID, data interface{}) error
+}
+
+// interface for webhook business logic
+type WebhookUseCase interface {
+	WebhookDispatcher
+	CreateWebhook(ownerID primitive.ObjectID, ownerRole string, req *CreateWebhookRequest) (*Webhook, error)
+	ListWebhooks(ownerID primitive.ObjectID) ([]*Webhook, error)
+	GetWebhook(id, userID primitive.ObjectID, userRole string) (*Webhook, error)
+	UpdateWebhook(id, userID primitive.ObjectID, userRole string, req *UpdateWebhookRequest) (*Webhook, error)
+	DeleteWebhook(id, userID primitive.ObjectID, userRole string) error
+	ListDeliveries(webhookID, userID primitive.ObjectID, userRole string, page, limit int) ([]*WebhookDelivery, int64, error)
+	Redeliver(webhookID, deliveryID, userID primitive.ObjectID, userRole string) (*WebhookDelivery, error)
+	ProcessDueDeliveries() (int, error)
+}
+
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" validate:"required,url,max=2048"`
+	Events []string `json:"events" validate:"required,min=1,dive,oneof=blog.created blog.published blog.updated blog.deleted comment.created reaction.changed"`
+	Global bool     `json:"global"`
+}
+
+// setting active to true re-enables a webhook that was disabled for failing
+type UpdateWebhookRequest struct {
+	URL    *string   `json:"url" validate:"omitempty,url,max=2048"`
+	Events *[]string `json:"events" validate:"omitempty,min=1,dive,oneof=blog.created blog.published blog.updated blog.deleted comment.created reaction.changed"`
+	Active *bool     `json:"active"`
+}
@@ -0,0 +1,39 @@
+package eventbus
+
+import (
+	"sync"
+
+	"Blog-API/internal/domain"
+)
+
+// in-process registry of domain event subscribers
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string]map[string]domain.EventHandler // event name -> subscriber -> handler
+}
+
+func NewBus() domain.EventBus {
+	return &Bus{handlers: make(map[string]map[string]domain.EventHandler)}
+}
+
+// registers handler for an event; subscribing the same name twice replaces the handler
+func (b *Bus) Subscribe(eventName, subscriber string, handler domain.EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.handlers[eventName] == nil {
+		b.handlers[eventName] = make(map[string]domain.EventHandler)
+	}
+	b.handlers[eventName][subscriber] = handler
+}
+
+func (b *Bus) Subscribers(eventName string) map[string]domain.EventHandler {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	handlers := make(map[string]domain.EventHandler, len(b.handlers[eventName]))
+	for subscriber, handler := range b.handlers[eventName] {
+		handlers[subscriber] = handler
+	}
+	return handlers
+}
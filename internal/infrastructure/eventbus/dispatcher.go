@@ -0,0 +1,147 @@
+package eventbus
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"Blog-API/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// how long a claimed message is hidden from other dispatchers while its handlers run
+	outboxLease = time.Minute
+	// upper bound on the wait between two attempts
+	maxOutboxBackoff = time.Hour
+	// messages handled per run
+	outboxBatchSize = 100
+)
+
+// hands outbox messages to the subscribers on the bus, at least once each
+type Dispatcher struct {
+	outbox      domain.OutboxRepository
+	bus         domain.EventBus
+	maxAttempts int
+	baseBackoff time.Duration
+}
+
+// a message whose handlers keep failing is retried up to maxAttempts times,
+// waiting baseBackoff and doubling each time
+func NewDispatcher(outbox domain.OutboxRepository, bus domain.EventBus, maxAttempts int, baseBackoff time.Duration) *Dispatcher {
+	return &Dispatcher{
+		outbox:      outbox,
+		bus:         bus,
+		maxAttempts: maxAttempts,
+		baseBackoff: baseBackoff,
+	}
+}
+
+// dispatches the messages that are due and returns how many were handled
+func (d *Dispatcher) ProcessPending() (int, error) {
+	processed := 0
+	for processed < outboxBatchSize {
+		message, err := d.outbox.ClaimPending(time.Now(), outboxLease)
+		if err != nil {
+			return processed, err
+		}
+		if message == nil {
+			return processed, nil
+		}
+		if err := d.dispatch(message); err != nil {
+			return processed, err
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// runs the subscribers that have not yet handled the message and records the outcome
+func (d *Dispatcher) dispatch(message *domain.OutboxMessage) error {
+	event, ok := domain.NewDomainEvent(message.Name)
+	if !ok {
+		return d.giveUp(message, fmt.Sprintf("unknown event %q", message.Name))
+	}
+	if err := json.Unmarshal([]byte(message.Payload), event); err != nil {
+		return d.giveUp(message, fmt.Sprintf("failed to decode event: %v", err))
+	}
+
+	subscribers := d.bus.Subscribers(message.Name)
+	names := make([]string, 0, len(subscribers))
+	for name := range subscribers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	failures := []string{}
+	for _, name := range names {
+		if containsName(message.Handled, name) {
+			continue
+		}
+		if err := safeHandle(subscribers[name], message.ID, event); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		message.Handled = append(message.Handled, name)
+	}
+
+	message.Attempts++
+	if len(failures) == 0 {
+		now := time.Now()
+		message.Status = domain.OutboxDispatched
+		message.LastError = ""
+		message.DispatchedAt = &now
+		return d.outbox.Update(message)
+	}
+
+	message.LastError = strings.Join(failures, "; ")
+	if message.Attempts >= d.maxAttempts {
+		log.Printf("giving up on %s event %s after %d attempts: %s", message.Name, message.ID.Hex(), message.Attempts, message.LastError)
+		message.Status = domain.OutboxFailed
+		return d.outbox.Update(message)
+	}
+	message.NextAttemptAt = time.Now().Add(d.backoff(message.Attempts))
+	return d.outbox.Update(message)
+}
+
+func (d *Dispatcher) giveUp(message *domain.OutboxMessage, reason string) error {
+	log.Printf("dropping outbox message %s: %s", message.ID.Hex(), reason)
+	message.Status = domain.OutboxFailed
+	message.LastError = reason
+	return d.outbox.Update(message)
+}
+
+// exponential backoff: base, 2*base, 4*base, ... capped at maxOutboxBackoff
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	wait := d.baseBackoff
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= maxOutboxBackoff {
+			return maxOutboxBackoff
+		}
+	}
+	return wait
+}
+
+// a panicking subscriber counts as a failed one instead of taking the worker down
+func safeHandle(handler domain.EventHandler, eventID primitive.ObjectID, event domain.DomainEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return handler(eventID, event)
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
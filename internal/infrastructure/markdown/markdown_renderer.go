@@ -0,0 +1,140 @@
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"Blog-API/internal/domain"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+const (
+	WordsPerMinute   = 200
+	MaxExcerptLength = 280
+)
+
+type MarkdownRenderer struct {
+	md     goldmark.Markdown
+	policy *bluemonday.Policy
+}
+
+func NewMarkdownRenderer() domain.ContentRenderer {
+	md := goldmark.New(
+		goldmark.WithExtensions(extension.GFM, extension.Footnote),
+		goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+	)
+
+	// start from the user generated content policy and allow the extra
+	// attributes goldmark emits for code blocks, footnotes and task lists
+	policy := bluemonday.UGCPolicy()
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^footnote(s|-ref|-backref)$`)).OnElements("a", "div")
+	policy.AllowAttrs("role").Matching(regexp.MustCompile(`^doc-(noteref|endnotes|backlink)$`)).OnElements("a", "div")
+	policy.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	policy.AllowAttrs("checked", "disabled").OnElements("input")
+
+	return &MarkdownRenderer{md: md, policy: policy}
+}
+
+// renders markdown source to sanitized HTML and derives its metadata
+func (r *MarkdownRenderer) Render(source string) (*domain.RenderedContent, error) {
+	src := []byte(source)
+	doc := r.md.Parser().Parse(text.NewReader(src))
+
+	var buf bytes.Buffer
+	if err := r.md.Renderer().Render(&buf, src, doc); err != nil {
+		return nil, err
+	}
+
+	var (
+		toc        []domain.TOCEntry
+		words      int
+		paragraphs []string
+	)
+
+	err := ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch node := n.(type) {
+		case *ast.Heading:
+			heading := plainText(node, src)
+			words += len(strings.Fields(heading))
+			entry := domain.TOCEntry{Level: node.Level, Text: heading}
+			if id, ok := node.AttributeString("id"); ok {
+				if b, ok := id.([]byte); ok {
+					entry.Anchor = string(b)
+				}
+			}
+			toc = append(toc, entry)
+			return ast.WalkSkipChildren, nil
+		case *ast.Paragraph, *ast.TextBlock:
+			para := plainText(node, src)
+			words += len(strings.Fields(para))
+			if _, inList := node.Parent().(*ast.ListItem); !inList && para != "" {
+				paragraphs = append(paragraphs, para)
+			}
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	readingTime := (words + WordsPerMinute - 1) / WordsPerMinute
+	if readingTime < 1 {
+		readingTime = 1
+	}
+
+	return &domain.RenderedContent{
+		HTML:        r.policy.Sanitize(buf.String()),
+		Excerpt:     excerpt(strings.Join(paragraphs, " "), MaxExcerptLength),
+		WordCount:   words,
+		ReadingTime: readingTime,
+		TOC:         toc,
+	}, nil
+}
+
+// collects the text of all inline descendants of a node
+func plainText(n ast.Node, src []byte) string {
+	var sb strings.Builder
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		switch t := c.(type) {
+		case *ast.Text:
+			sb.Write(t.Segment.Value(src))
+			if t.SoftLineBreak() || t.HardLineBreak() {
+				sb.WriteByte(' ')
+			}
+		case *ast.String:
+			sb.Write(t.Value)
+		case *ast.RawHTML:
+			// raw HTML is not part of the readable text
+		default:
+			sb.WriteString(plainText(c, src))
+		}
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// truncates text to at most max characters on a word boundary
+func excerpt(s string, max int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	runes := []rune(s)[:max]
+	cut := string(runes)
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, ".,;:!?-") + "…"
+}
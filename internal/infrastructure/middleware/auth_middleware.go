@@ -24,49 +24,58 @@ func NewAuthMiddleware(jwtService domain.JWTService, sessionRepo domain.SessionR
 //  checks if user is authenticated
 func (a *AuthMiddleware) AuthRequired() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		token := extractToken(c)
-		if token == "" {
-			c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "Authorization token required"})
-			c.Abort()
+		if !a.authenticate(c) {
 			return
 		}
 
-		claims, err := a.jwtService.ValidateToken(token)
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "Invalid or expired token"})
-			c.Abort()
-			return
-		}
+		c.Next()
+	}
+}
 
-		// Additional security: Check if session exists and is active
-		session, err := a.sessionRepo.GetByUserID(claims.UserID)
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "Session not found"})
-			c.Abort()
-			return
-		}
-		
-		if !session.IsActive {
-			c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "Session inactive"})
-			c.Abort()
-			return
-		}
+// validates the token and session and stores the user info in the context;
+// aborts the request and returns false when the user is not authenticated
+func (a *AuthMiddleware) authenticate(c *gin.Context) bool {
+	token := extractToken(c)
+	if token == "" {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "Authorization token required"})
+		c.Abort()
+		return false
+	}
 
-		// Set user info in context
-		c.Set("user_id", claims.UserID)
-		c.Set("user_email", claims.Email)
-		c.Set("user_role", claims.Role)
+	claims, err := a.jwtService.ValidateToken(token)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "Invalid or expired token"})
+		c.Abort()
+		return false
+	}
 
-		c.Next()
+	// Additional security: Check if session exists and is active
+	session, err := a.sessionRepo.GetByUserID(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "Session not found"})
+		c.Abort()
+		return false
 	}
+
+	if !session.IsActive {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "Session inactive"})
+		c.Abort()
+		return false
+	}
+
+	// Set user info in context
+	c.Set("user_id", claims.UserID)
+	c.Set("user_email", claims.Email)
+	c.Set("user_role", claims.Role)
+
+	return true
 }
 
 // checks if user is admin
 func (a *AuthMiddleware) AdminRequired() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// First check if user is authenticated
-		a.AuthRequired()(c)
-		if c.IsAborted() {
+		// First check if user is authenticated, without running the rest of the chain yet
+		if !a.authenticate(c) {
 			return
 		}
 
@@ -0,0 +1,127 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"Blog-API/internal/domain"
+)
+
+type subscriber struct {
+	topics map[string]bool
+	events chan domain.Event
+}
+
+// in-process pub/sub hub that keeps a bounded history so reconnecting clients can resume
+type Hub struct {
+	mu          sync.Mutex
+	lastID      uint64
+	subscribers map[*subscriber]struct{}
+	history     []domain.Event // ring buffer of the most recent events
+	next        int            // position of the next write in history
+	full        bool
+	bufferSize  int
+}
+
+// bufferSize is the number of undelivered events a subscriber may have before it is dropped,
+// historySize the number of events kept for Last-Event-ID replay
+func NewHub(bufferSize, historySize int) domain.PubSub {
+	if bufferSize < 1 {
+		bufferSize = 1
+	}
+	if historySize < 1 {
+		historySize = 1
+	}
+	return &Hub{
+		// seeding with the clock keeps IDs increasing across restarts
+		lastID:      uint64(time.Now().UnixMilli()) * 1000,
+		subscribers: make(map[*subscriber]struct{}),
+		history:     make([]domain.Event, historySize),
+		bufferSize:  bufferSize,
+	}
+}
+
+func (h *Hub) Publish(topic, eventType string, data interface{}) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("pubsub: failed to encode %s event: %v", eventType, err)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.lastID++
+	event := domain.Event{ID: h.lastID, Topic: topic, Type: eventType, Data: payload, CreatedAt: time.Now()}
+	h.history[h.next] = event
+	h.next = (h.next + 1) % len(h.history)
+	if h.next == 0 {
+		h.full = true
+	}
+
+	for sub := range h.subscribers {
+		if !sub.topics[topic] {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			// the client is not keeping up; drop it so it reconnects and resumes from its last event
+			h.remove(sub)
+		}
+	}
+}
+
+func (h *Hub) Subscribe(topics []string, lastEventID uint64) (<-chan domain.Event, func()) {
+	sub := &subscriber{topics: make(map[string]bool, len(topics))}
+	for _, t := range topics {
+		sub.topics[t] = true
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// replay and registration happen under the same lock so no event is missed in between
+	var replay []domain.Event
+	if lastEventID > 0 {
+		replay = h.since(lastEventID, sub.topics)
+	}
+	sub.events = make(chan domain.Event, h.bufferSize+len(replay))
+	for _, event := range replay {
+		sub.events <- event
+	}
+	h.subscribers[sub] = struct{}{}
+
+	cancel := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.remove(sub)
+	}
+	return sub.events, cancel
+}
+
+// retained events newer than id on the given topics, oldest first
+func (h *Hub) since(id uint64, topics map[string]bool) []domain.Event {
+	start, count := 0, h.next
+	if h.full {
+		start, count = h.next, len(h.history)
+	}
+	var events []domain.Event
+	for i := 0; i < count; i++ {
+		event := h.history[(start+i)%len(h.history)]
+		if event.ID > id && topics[event.Topic] {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// must be called with the lock held
+func (h *Hub) remove(sub *subscriber) {
+	if _, ok := h.subscribers[sub]; ok {
+		delete(h.subscribers, sub)
+		close(sub.events)
+	}
+}
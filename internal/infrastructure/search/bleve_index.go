@@ -0,0 +1,364 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"Blog-API/internal/domain"
+	"Blog-API/pkg/highlight"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
+	"github.com/blevesearch/bleve/v2/analysis/lang/de"
+	"github.com/blevesearch/bleve/v2/analysis/lang/en"
+	"github.com/blevesearch/bleve/v2/analysis/lang/es"
+	"github.com/blevesearch/bleve/v2/analysis/lang/fr"
+	"github.com/blevesearch/bleve/v2/analysis/lang/it"
+	"github.com/blevesearch/bleve/v2/analysis/lang/nl"
+	"github.com/blevesearch/bleve/v2/analysis/lang/pt"
+	"github.com/blevesearch/bleve/v2/mapping"
+	blevesearch "github.com/blevesearch/bleve/v2/search"
+	"github.com/blevesearch/bleve/v2/search/query"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// analyzers that stem titles and content, by SEARCH_LANGUAGE
+var languageAnalyzers = map[string]string{
+	"en": en.AnalyzerName,
+	"fr": fr.AnalyzerName,
+	"de": de.AnalyzerName,
+	"es": es.AnalyzerName,
+	"it": it.AnalyzerName,
+	"pt": pt.AnalyzerName,
+	"nl": nl.AnalyzerName,
+}
+
+// terms listed per facet
+const facetSize = 10
+
+// relevance tuning; boosts are applied at query time so they take effect without a reindex
+type Options struct {
+	Language     string  // key of languageAnalyzers, fixed when the index is created
+	TitleBoost   float64 // weight of a title match relative to a content match
+	TagBoost     float64 // weight of a tag match relative to a content match
+	MaxFuzziness int     // most typos tolerated per word
+}
+
+type BleveIndex struct {
+	index bleve.Index
+	opts  Options
+}
+
+// what the index stores for a blog
+type blogDocument struct {
+	Title   string   `json:"title"`
+	Content string   `json:"content"`  // plain text of the rendered content
+	TagText string   `json:"tag_text"` // tags as words, for matching
+	Tags    []string `json:"tags"`     // tags as they are, for filters and facets
+	Author  string   `json:"author"`
+	Year    string   `json:"year"`
+}
+
+// opens the index at path, creating it when it does not exist yet
+func NewBleveIndex(path string, opts Options) (domain.SearchIndex, error) {
+	if opts.Language == "" {
+		opts.Language = "en"
+	}
+	if _, ok := languageAnalyzers[opts.Language]; !ok {
+		return nil, fmt.Errorf("unsupported search language %q", opts.Language)
+	}
+
+	index, err := bleve.Open(path)
+	if errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+		index, err = bleve.New(path, newIndexMapping(languageAnalyzers[opts.Language]))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open search index: %w", err)
+	}
+	return &BleveIndex{index: index, opts: opts}, nil
+}
+
+func newIndexMapping(analyzer string) mapping.IndexMapping {
+	text := bleve.NewTextFieldMapping()
+	text.Analyzer = analyzer
+	text.Store = true // highlighting needs the original text
+	text.IncludeInAll = false
+
+	words := bleve.NewTextFieldMapping()
+	words.Analyzer = analyzer
+	words.Store = false
+	words.IncludeInAll = false
+
+	exact := bleve.NewTextFieldMapping()
+	exact.Analyzer = keyword.Name
+	exact.Store = false
+	exact.IncludeTermVectors = false
+	exact.IncludeInAll = false
+
+	blog := bleve.NewDocumentStaticMapping()
+	blog.AddFieldMappingsAt("title", text)
+	blog.AddFieldMappingsAt("content", text)
+	blog.AddFieldMappingsAt("tag_text", words)
+	blog.AddFieldMappingsAt("tags", exact)
+	blog.AddFieldMappingsAt("author", exact)
+	blog.AddFieldMappingsAt("year", exact)
+
+	indexMapping := bleve.NewIndexMapping()
+	indexMapping.DefaultMapping = blog
+	indexMapping.DefaultAnalyzer = analyzer
+	return indexMapping
+}
+
+func (b *BleveIndex) Index(blogs ...*domain.Blog) error {
+	batch := b.index.NewBatch()
+	for _, blog := range blogs {
+		if err := batch.Index(blog.ID.Hex(), newBlogDocument(blog)); err != nil {
+			return fmt.Errorf("failed to index blog: %w", err)
+		}
+	}
+	if err := b.index.Batch(batch); err != nil {
+		return fmt.Errorf("failed to index blogs: %w", err)
+	}
+	return nil
+}
+
+func (b *BleveIndex) Remove(id primitive.ObjectID) error {
+	if err := b.index.Delete(id.Hex()); err != nil {
+		return fmt.Errorf("failed to remove blog from search index: %w", err)
+	}
+	return nil
+}
+
+func (b *BleveIndex) Count() (uint64, error) {
+	return b.index.DocCount()
+}
+
+func (b *BleveIndex) Close() error {
+	return b.index.Close()
+}
+
+func (b *BleveIndex) Search(q *domain.SearchQuery) (*domain.SearchResults, error) {
+	request := bleve.NewSearchRequestOptions(b.buildQuery(q), q.Limit, (q.Page-1)*q.Limit, false)
+	request.Highlight = bleve.NewHighlightWithStyle("html")
+	request.Highlight.AddField("title")
+	request.Highlight.AddField("content")
+	request.AddFacet("tags", bleve.NewFacetRequest("tags", facetSize))
+	request.AddFacet("authors", bleve.NewFacetRequest("author", facetSize))
+	request.AddFacet("years", bleve.NewFacetRequest("year", facetSize))
+
+	result, err := b.index.Search(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+
+	results := &domain.SearchResults{Hits: []domain.SearchHit{}, Total: int64(result.Total)}
+	for _, hit := range result.Hits {
+		id, err := primitive.ObjectIDFromHex(hit.ID)
+		if err != nil {
+			continue
+		}
+		results.Hits = append(results.Hits, domain.SearchHit{ID: id, Score: hit.Score, Fragments: hit.Fragments})
+	}
+	results.Facets = domain.SearchFacets{
+		Tags:    facetCounts(result.Facets["tags"]),
+		Authors: facetCounts(result.Facets["authors"]),
+		Years:   facetCounts(result.Facets["years"]),
+	}
+	return results, nil
+}
+
+// every word or phrase of the query must match in the title, tags or content, none of the
+// excluded ones may, and the filters must hold
+func (b *BleveIndex) buildQuery(q *domain.SearchQuery) query.Query {
+	var must, mustNot []query.Query
+
+	terms := parseQuery(q.Query)
+	last := -1
+	for i, term := range terms {
+		if !term.excluded && !term.phrase {
+			last = i
+		}
+	}
+	for i, term := range terms {
+		switch {
+		case term.excluded:
+			mustNot = append(mustNot, b.fieldsQuery(term.text, term.phrase, false, false))
+		case !b.hasTokens(term.text):
+			// stop words are not indexed, requiring them would match nothing
+		default:
+			// the last word may still be being typed
+			must = append(must, b.fieldsQuery(term.text, term.phrase, true, i == last))
+		}
+	}
+
+	if len(q.Tags) > 0 {
+		tags := make([]query.Query, 0, len(q.Tags))
+		for _, tag := range q.Tags {
+			tags = append(tags, termQuery("tags", tag))
+		}
+		must = append(must, bleve.NewDisjunctionQuery(tags...))
+	}
+	if q.Author != "" {
+		must = append(must, termQuery("author", q.Author))
+	}
+	if q.Year != 0 {
+		must = append(must, termQuery("year", strconv.Itoa(q.Year)))
+	}
+
+	if len(must) == 0 {
+		must = append(must, bleve.NewMatchAllQuery())
+	}
+	boolean := bleve.NewBooleanQuery()
+	boolean.AddMust(must...)
+	if len(mustNot) > 0 {
+		boolean.AddMustNot(mustNot...)
+	}
+	return boolean
+}
+
+// matches text in any of the searched fields, weighted by field. Exact matches score
+// above the ones that needed typo tolerance.
+func (b *BleveIndex) fieldsQuery(text string, phrase, fuzzy, prefix bool) query.Query {
+	fields := []struct {
+		name  string
+		boost float64
+	}{
+		{"title", b.opts.TitleBoost},
+		{"tag_text", b.opts.TagBoost},
+		{"content", 1},
+	}
+	fuzziness := 0
+	if fuzzy {
+		fuzziness = b.fuzziness(text)
+	}
+
+	var alternatives []query.Query
+	for _, field := range fields {
+		if field.boost <= 0 {
+			continue
+		}
+		if phrase {
+			q := bleve.NewMatchPhraseQuery(text)
+			q.SetField(field.name)
+			q.SetBoost(field.boost)
+			alternatives = append(alternatives, q)
+			continue
+		}
+
+		exact := bleve.NewMatchQuery(text)
+		exact.SetField(field.name)
+		exact.SetBoost(2 * field.boost)
+		alternatives = append(alternatives, exact)
+		if fuzziness > 0 {
+			typo := bleve.NewMatchQuery(text)
+			typo.SetField(field.name)
+			typo.SetBoost(field.boost)
+			typo.Fuzziness = fuzziness
+			alternatives = append(alternatives, typo)
+		}
+		if prefix {
+			started := bleve.NewPrefixQuery(strings.ToLower(text))
+			started.SetField(field.name)
+			started.SetBoost(field.boost)
+			alternatives = append(alternatives, started)
+		}
+	}
+	return bleve.NewDisjunctionQuery(alternatives...)
+}
+
+// short words would match too much with typos allowed
+func (b *BleveIndex) fuzziness(word string) int {
+	n := utf8.RuneCountInString(word)
+	fuzziness := 0
+	switch {
+	case n >= 8:
+		fuzziness = 2
+	case n >= 4:
+		fuzziness = 1
+	}
+	return min(fuzziness, b.opts.MaxFuzziness)
+}
+
+// reports whether the analyzer keeps anything of text
+func (b *BleveIndex) hasTokens(text string) bool {
+	analyzer := b.index.Mapping().AnalyzerNamed(b.index.Mapping().AnalyzerNameForPath("content"))
+	if analyzer == nil {
+		return true
+	}
+	return len(analyzer.Analyze([]byte(text))) > 0
+}
+
+func termQuery(field, term string) query.Query {
+	q := bleve.NewTermQuery(term)
+	q.SetField(field)
+	return q
+}
+
+func newBlogDocument(blog *domain.Blog) *blogDocument {
+	// blogs written before content was rendered only have the markdown source
+	content := blog.Content
+	if blog.ContentHTML != "" {
+		content = highlight.Text(blog.ContentHTML)
+	}
+	return &blogDocument{
+		Title:   blog.Title,
+		Content: content,
+		TagText: strings.ReplaceAll(strings.Join(blog.Tags, " "), "-", " "),
+		Tags:    blog.Tags,
+		Author:  blog.AuthorUsername,
+		Year:    strconv.Itoa(blog.CreatedAt.Year()),
+	}
+}
+
+func facetCounts(facet *blevesearch.FacetResult) []domain.FacetCount {
+	counts := []domain.FacetCount{}
+	if facet == nil || facet.Terms == nil {
+		return counts
+	}
+	for _, term := range facet.Terms.Terms() {
+		counts = append(counts, domain.FacetCount{Term: term.Term, Count: term.Count})
+	}
+	return counts
+}
+
+type queryTerm struct {
+	text     string
+	phrase   bool
+	excluded bool
+}
+
+// splits a query into words, "quoted phrases" and their -excluded forms
+func parseQuery(raw string) []queryTerm {
+	var terms []queryTerm
+	for raw != "" {
+		raw = strings.TrimLeftFunc(raw, unicode.IsSpace)
+		if raw == "" {
+			break
+		}
+		term := queryTerm{}
+		if strings.HasPrefix(raw, "-") {
+			term.excluded = true
+			raw = raw[1:]
+		}
+		if strings.HasPrefix(raw, `"`) {
+			term.phrase = true
+			if end := strings.Index(raw[1:], `"`); end >= 0 {
+				term.text, raw = raw[1:end+1], raw[end+2:]
+			} else {
+				term.text, raw = raw[1:], ""
+			}
+		} else if end := strings.IndexFunc(raw, unicode.IsSpace); end >= 0 {
+			term.text, raw = raw[:end], raw[end:]
+		} else {
+			term.text, raw = raw, ""
+		}
+		if term.text = strings.TrimSpace(term.text); term.text != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
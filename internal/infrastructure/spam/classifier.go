@@ -0,0 +1,168 @@
+package spam
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"Blog-API/internal/domain"
+)
+
+// how far the heuristic signals can raise the model's score on their own
+const (
+	linkWeight = 0.5
+	ageWeight  = 0.3
+)
+
+// the Bayes score stays neutral until the model has seen this many samples of each label
+const minSamplesPerLabel = 5
+
+// number of links at which the link signal saturates
+const maxLinks = 3
+
+// the most telling tokens used when combining token probabilities
+const maxInterestingTokens = 15
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)([a-z0-9.-]+\.[a-z]{2,})`)
+
+type classifier struct {
+	modelRepo      domain.SpamModelRepository
+	blockedDomains []string
+}
+
+func NewClassifier(modelRepo domain.SpamModelRepository, blockedDomains []string) domain.SpamClassifier {
+	domains := make([]string, 0, len(blockedDomains))
+	for _, d := range blockedDomains {
+		if d = strings.ToLower(strings.TrimSpace(d)); d != "" {
+			domains = append(domains, d)
+		}
+	}
+	return &classifier{modelRepo: modelRepo, blockedDomains: domains}
+}
+
+// scores a comment from the trained model, the links it contains and the age of the author's account
+func (c *classifier) Classify(content string, author *domain.User) (*domain.SpamVerdict, error) {
+	verdict := &domain.SpamVerdict{}
+
+	bayes, err := c.bayesScore(tokenize(content))
+	if err != nil {
+		return nil, err
+	}
+	verdict.BayesScore = bayes
+
+	for _, match := range linkPattern.FindAllStringSubmatch(content, -1) {
+		verdict.LinkCount++
+		if verdict.BlockedDomain == "" && c.isBlocked(match[1]) {
+			verdict.BlockedDomain = strings.ToLower(match[1])
+		}
+	}
+	linkScore := math.Min(float64(verdict.LinkCount)/maxLinks, 1)
+
+	ageScore := 0.0
+	if author != nil && !author.CreatedAt.IsZero() {
+		age := time.Since(author.CreatedAt)
+		verdict.AccountAgeDays = age.Hours() / 24
+		switch {
+		case age < time.Hour:
+			ageScore = 1
+		case age < 24*time.Hour:
+			ageScore = 0.6
+		case age < 7*24*time.Hour:
+			ageScore = 0.3
+		}
+	}
+
+	// each heuristic removes part of the remaining doubt, so a confident model
+	// decides alone while links and a new account tip borderline comments
+	verdict.Score = 1 - (1-bayes)*(1-linkWeight*linkScore)*(1-ageWeight*ageScore)
+	// a link to a known spam domain is enough on its own
+	if verdict.BlockedDomain != "" {
+		verdict.Score = math.Max(verdict.Score, 0.99)
+	}
+	return verdict, nil
+}
+
+func (c *classifier) Train(content, label string) error {
+	return c.modelRepo.AddSamples(tokenize(content), label, 1)
+}
+
+// reverses an earlier Train call, used when a moderator changes their mind
+func (c *classifier) Untrain(content, label string) error {
+	return c.modelRepo.AddSamples(tokenize(content), label, -1)
+}
+
+// naive Bayes probability that the tokens come from spam, 0.5 when the model knows too little
+func (c *classifier) bayesScore(tokens []string) (float64, error) {
+	spamDocs, hamDocs, err := c.modelRepo.GetDocumentCounts()
+	if err != nil {
+		return 0, err
+	}
+	if spamDocs < minSamplesPerLabel || hamDocs < minSamplesPerLabel || len(tokens) == 0 {
+		return 0.5, nil
+	}
+
+	stats, err := c.modelRepo.GetTokenStats(tokens)
+	if err != nil {
+		return 0, err
+	}
+
+	// per-token spam probability with Laplace smoothing, keeping the ones furthest from neutral
+	probs := make([]float64, 0, len(stats))
+	for _, s := range stats {
+		if s.Spam+s.Ham == 0 {
+			continue
+		}
+		pSpam := float64(s.Spam+1) / float64(spamDocs+2)
+		pHam := float64(s.Ham+1) / float64(hamDocs+2)
+		probs = append(probs, pSpam/(pSpam+pHam))
+	}
+	if len(probs) == 0 {
+		return 0.5, nil
+	}
+	sort.Slice(probs, func(i, j int) bool {
+		return math.Abs(probs[i]-0.5) > math.Abs(probs[j]-0.5)
+	})
+	if len(probs) > maxInterestingTokens {
+		probs = probs[:maxInterestingTokens]
+	}
+
+	// combine in log space to avoid underflow
+	logSpam := math.Log(float64(spamDocs) / float64(spamDocs+hamDocs))
+	logHam := math.Log(float64(hamDocs) / float64(spamDocs+hamDocs))
+	for _, p := range probs {
+		logSpam += math.Log(p)
+		logHam += math.Log(1 - p)
+	}
+	return 1 / (1 + math.Exp(logHam-logSpam)), nil
+}
+
+func (c *classifier) isBlocked(host string) bool {
+	host = strings.ToLower(host)
+	for _, d := range c.blockedDomains {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+// lower-cased distinct words of the content, links reduced to their host
+func tokenize(content string) []string {
+	content = linkPattern.ReplaceAllString(content, " link:$1 ")
+	seen := map[string]bool{}
+	tokens := []string{}
+	for _, word := range strings.FieldsFunc(strings.ToLower(content), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != ':' && r != '.' && r != '-'
+	}) {
+		word = strings.Trim(word, ".:-")
+		if len([]rune(word)) < 2 || len(word) > 40 || seen[word] {
+			continue
+		}
+		seen[word] = true
+		tokens = append(tokens, word)
+	}
+	return tokens
+}
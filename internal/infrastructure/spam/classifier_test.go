@@ -0,0 +1,152 @@
+package spam
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"Blog-API/internal/domain"
+)
+
+// in-memory stand-in for the model collection
+type memModelRepo struct {
+	docs   map[string]int
+	tokens map[string]*domain.SpamTokenStats
+}
+
+func newMemModelRepo() *memModelRepo {
+	return &memModelRepo{docs: map[string]int{}, tokens: map[string]*domain.SpamTokenStats{}}
+}
+
+func (r *memModelRepo) GetDocumentCounts() (int, int, error) {
+	return r.docs[domain.SpamLabelSpam], r.docs[domain.SpamLabelHam], nil
+}
+
+func (r *memModelRepo) GetTokenStats(tokens []string) (map[string]domain.SpamTokenStats, error) {
+	stats := map[string]domain.SpamTokenStats{}
+	for _, token := range tokens {
+		if s, ok := r.tokens[token]; ok {
+			stats[token] = *s
+		}
+	}
+	return stats, nil
+}
+
+func (r *memModelRepo) AddSamples(tokens []string, label string, delta int) error {
+	r.docs[label] += delta
+	for _, token := range tokens {
+		s, ok := r.tokens[token]
+		if !ok {
+			s = &domain.SpamTokenStats{Token: token}
+			r.tokens[token] = s
+		}
+		if label == domain.SpamLabelSpam {
+			s.Spam += delta
+		} else {
+			s.Ham += delta
+		}
+	}
+	return nil
+}
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		content string
+		want    []string
+	}{
+		{"Hello, hello WORLD!", []string{"hello", "world"}},
+		{"buy now at https://Cheap-Pills.example.com/offer", []string{"buy", "now", "at", "link:cheap-pills.example.com", "offer"}},
+		{"a b c de", []string{"de"}},
+		{"...trailing dots... and -dashes-", []string{"trailing", "dots", "and", "dashes"}},
+		{"", []string{}},
+	}
+	for _, tt := range tests {
+		if got := tokenize(tt.content); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("tokenize(%q) = %q, want %q", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestClassifyHeuristics(t *testing.T) {
+	old := &domain.User{CreatedAt: time.Now().Add(-365 * 24 * time.Hour)}
+	tests := []struct {
+		name      string
+		content   string
+		author    *domain.User
+		wantScore float64
+		wantLinks int
+		blocked   string
+	}{
+		{"untrained model stays neutral", "nice post", old, 0.5, 0, ""},
+		{"links raise the score", "see http://a.example and http://b.example", old, 1 - 0.5*(1-linkWeight*2.0/3), 2, ""},
+		{"links saturate", "http://a.io http://b.io http://c.io http://d.io", old, 1 - 0.5*(1-linkWeight), 4, ""},
+		{"brand new account", "nice post", &domain.User{CreatedAt: time.Now().Add(-time.Minute)}, 1 - 0.5*(1-ageWeight), 0, ""},
+		{"day old account", "nice post", &domain.User{CreatedAt: time.Now().Add(-2 * time.Hour)}, 1 - 0.5*(1-ageWeight*0.6), 0, ""},
+		{"unknown author age", "nice post", &domain.User{}, 0.5, 0, ""},
+		{"blocked domain decides alone", "visit https://www.Spam.test/x", old, 0.99, 1, "www.spam.test"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClassifier(newMemModelRepo(), []string{" spam.test "})
+			verdict, err := c.Classify(tt.content, tt.author)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(verdict.Score-tt.wantScore) > 1e-9 {
+				t.Fatalf("got score %v, want %v", verdict.Score, tt.wantScore)
+			}
+			if verdict.LinkCount != tt.wantLinks || verdict.BlockedDomain != tt.blocked {
+				t.Fatalf("got %d links, blocked %q; want %d, %q", verdict.LinkCount, verdict.BlockedDomain, tt.wantLinks, tt.blocked)
+			}
+		})
+	}
+}
+
+func TestClassifyLearns(t *testing.T) {
+	repo := newMemModelRepo()
+	c := NewClassifier(repo, nil)
+	spam := []string{"cheap pills discount offer", "discount casino bonus offer", "cheap casino pills", "free bonus offer now", "pills discount free"}
+	ham := []string{"great write up on goroutines", "thanks for the clear explanation", "the benchmark section was helpful", "goroutines and channels explained well", "clear and helpful post"}
+	for i := range spam {
+		if err := c.Train(spam[i], domain.SpamLabelSpam); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.Train(ham[i], domain.SpamLabelHam); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		content string
+		spam    bool
+	}{
+		{"cheap discount pills offer", true},
+		{"free casino bonus", true},
+		{"helpful explanation of goroutines", false},
+		{"clear benchmark write up", false},
+	}
+	for _, tt := range tests {
+		verdict, err := c.Classify(tt.content, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (verdict.BayesScore > 0.5) != tt.spam || math.Abs(verdict.Score-verdict.BayesScore) > 1e-9 {
+			t.Errorf("%q: got bayes %v score %v, want spam=%v", tt.content, verdict.BayesScore, verdict.Score, tt.spam)
+		}
+	}
+
+	// untraining the spam samples takes the model back below the minimum
+	for _, content := range spam {
+		if err := c.Untrain(content, domain.SpamLabelSpam); err != nil {
+			t.Fatal(err)
+		}
+	}
+	verdict, err := c.Classify("cheap discount pills offer", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verdict.BayesScore != 0.5 {
+		t.Fatalf("got bayes %v after untraining, want 0.5", verdict.BayesScore)
+	}
+}
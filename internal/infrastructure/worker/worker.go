@@ -0,0 +1,39 @@
+package worker
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// runs job every interval in the background until the returned stop function is called.
+// A non-positive interval never runs the job rather than panicking in time.NewTicker.
+func Every(name string, interval time.Duration, job func() error) (stop func()) {
+	if interval <= 0 {
+		log.Printf("worker %s not started: interval %v is not positive", name, interval)
+		return func() {}
+	}
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				if err := job(); err != nil {
+					log.Printf("worker %s failed: %v", name, err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			ticker.Stop()
+			close(done)
+		})
+	}
+}
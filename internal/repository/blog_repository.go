@@ -5,6 +5,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
 	"time"
 
 	"Blog-API/internal/infrastructure/database"
@@ -18,23 +23,136 @@ import (
 type BlogRepo struct {
 	db         *database.MongoDB
 	collection *mongo.Collection
+	ctx        context.Context // set by WithContext, e.g. to join a transaction
+}
+
+// weights of a title and a tag match in the text index behind GET /blogs/search,
+// relative to a content match
+type TextSearchWeights struct {
+	Title int
+	Tags  int
 }
 
 // CORRECTED: The constructor now returns the interface type and takes the standard *mongo.Database.
-func NewBlogRepository(db *database.MongoDB) domain.BlogRepository {
+func NewBlogRepository(db *database.MongoDB, textWeights TextSearchWeights) domain.BlogRepository {
 	// CORRECTED: Collection names are conventionally lowercase.
 	collection := db.GetCollection("blogs")
+
+	indexModels := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "slug", Value: 1}},
+			Options: options.Index().SetUnique(true).
+				SetPartialFilterExpression(bson.M{"slug": bson.M{"$type": "string"}}),
+		},
+		{
+			Keys: bson.D{{Key: "slug_history", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "collaborators.user_id", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "author_id", Value: 1}, {Key: "deleted_at", Value: -1}},
+		},
+		// listings always filter on deleted_at, so it leads or follows the equality
+		// fields of the compound indexes below, before the sort keys
+		{
+			Keys: bson.D{{Key: "deleted_at", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "deleted_at", Value: 1}, {Key: "like_count", Value: -1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "deleted_at", Value: 1}, {Key: "view_count", Value: -1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "deleted_at", Value: 1}, {Key: "comment_count", Value: -1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "tags", Value: 1}, {Key: "deleted_at", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "author_username", Value: 1}, {Key: "deleted_at", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "co_authors", Value: 1}, {Key: "deleted_at", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "category_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+	}
+	// indexes might already exist, so an error here is not fatal
+	_, _ = collection.Indexes().CreateMany(context.Background(), indexModels)
+
+	if err := ensureTextIndex(collection, textWeights); err != nil {
+		log.Printf("Failed to create the blog text index: %v", err)
+	}
+
 	return &BlogRepo{db: db, collection: collection}
 }
 
+const textIndexName = "blog_text_search"
+
+// a collection has at most one text index and its weights cannot be changed in place, so
+// a text index with other weights, or an older one under another name, is dropped and
+// created again with the configured weights
+func ensureTextIndex(collection *mongo.Collection, weights TextSearchWeights) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	want := map[string]int{"title": weights.Title, "tags": weights.Tags, "content": 1}
+
+	curr, err := collection.Indexes().List(ctx)
+	if err != nil {
+		return err
+	}
+	defer curr.Close(ctx)
+
+	for curr.Next(ctx) {
+		var index struct {
+			Name    string         `bson:"name"`
+			Key     bson.M         `bson:"key"`
+			Weights map[string]int `bson:"weights"`
+		}
+		if err := curr.Decode(&index); err != nil {
+			return err
+		}
+		if index.Key["_fts"] != "text" {
+			continue
+		}
+		if index.Name == textIndexName && maps.Equal(index.Weights, want) {
+			return nil
+		}
+		if _, err := collection.Indexes().DropOne(ctx, index.Name); err != nil {
+			return fmt.Errorf("failed to drop text index %s: %w", index.Name, err)
+		}
+	}
+	if err := curr.Err(); err != nil {
+		return err
+	}
+
+	_, err = collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "title", Value: "text"}, {Key: "tags", Value: "text"}, {Key: "content", Value: "text"}},
+		Options: options.Index().SetName(textIndexName).
+			SetWeights(bson.M{"title": weights.Title, "tags": weights.Tags, "content": 1}),
+	})
+	return err
+}
+
+// returns a copy of the repository whose operations run within ctx
+func (br *BlogRepo) WithContext(ctx context.Context) domain.BlogRepository {
+	clone := *br
+	clone.ctx = ctx
+	return &clone
+}
+
 func (br *BlogRepo) Create(blog *domain.Blog) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
 	defer cancel()
 
 	_, err := br.collection.InsertOne(ctx, blog)
 	if err != nil {
 		if mongo.IsDuplicateKeyError(err) {
-			return fmt.Errorf("blog with this ID already exists: %w", err)
+			return fmt.Errorf("blog with this ID or slug already exists: %w", err)
 		}
 		if mongo.IsTimeout(err) {
 			return fmt.Errorf("database operation timed out: %w", err)
@@ -48,11 +166,11 @@ func (br *BlogRepo) Create(blog *domain.Blog) error {
 }
 
 func (br *BlogRepo) GetByID(id primitive.ObjectID) (*domain.Blog, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
 	defer cancel()
 
 	var blog domain.Blog
-	filter := bson.M{"_id": id}
+	filter := bson.M{"_id": id, "deleted_at": nil}
 
 	err := br.collection.FindOne(ctx, filter).Decode(&blog)
 	if err != nil {
@@ -64,34 +182,91 @@ func (br *BlogRepo) GetByID(id primitive.ObjectID) (*domain.Blog, error) {
 	return &blog, nil
 }
 
-func (br *BlogRepo) GetAll(page, limit int, sort string) ([]*domain.Blog, int64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// finds a blog by its current slug, falling back to slugs it used to have
+func (br *BlogRepo) GetBySlug(slug string) (*domain.Blog, error) {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
 	defer cancel()
 
-	var blogs []*domain.Blog
-	// CORRECTED: An empty BSON document matches all.
-	filter := bson.D{}
-	opts := options.Find()
-	opts.SetLimit(int64(limit))
-	opts.SetSkip(int64(page-1) * int64(limit))
+	var blog domain.Blog
+	err := br.collection.FindOne(ctx, bson.M{"slug": slug, "deleted_at": nil}).Decode(&blog)
+	if err == mongo.ErrNoDocuments {
+		err = br.collection.FindOne(ctx, bson.M{"slug_history": slug, "deleted_at": nil}).Decode(&blog)
+	}
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("blog not found")
+		}
+		return nil, fmt.Errorf("database error in GetBySlug: %w", err)
+	}
+	return &blog, nil
+}
 
-	// A simple sort implementation
-	if sort == "popular" {
-		opts.SetSort(bson.D{{Key: "like_count", Value: -1}})
-	} else {
-		opts.SetSort(bson.D{{Key: "created_at", Value: -1}})
+// fetches the blogs with the given IDs, in no particular order
+func (br *BlogRepo) GetByIDs(ids []primitive.ObjectID) ([]*domain.Blog, error) {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
+	defer cancel()
+
+	blogs := []*domain.Blog{}
+	if len(ids) == 0 {
+		return blogs, nil
+	}
+
+	curr, err := br.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}, "deleted_at": nil})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find blogs: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	if err := curr.All(ctx, &blogs); err != nil {
+		return nil, err
+	}
+	return blogs, nil
+}
+
+// reports whether a slug is in use, currently or historically, by a blog other than excludeID.
+// Blogs in the trash keep their slugs so they can be restored.
+func (br *BlogRepo) SlugTaken(slug string, excludeID primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"_id": bson.M{"$ne": excludeID},
+		"$or": bson.A{
+			bson.M{"slug": slug},
+			bson.M{"slug_history": slug},
+		},
+	}
+	count, err := br.collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("database error in SlugTaken: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (br *BlogRepo) List(params *domain.ListBlogParams) ([]*domain.Blog, int64, error) {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
+	defer cancel()
+
+	filter := listFilter(params)
+	opts := options.Find()
+	opts.SetSort(listSort(params.SortBy))
+	opts.SetLimit(int64(params.Limit))
+	opts.SetSkip(int64(params.Page-1) * int64(params.Limit))
+	if params.Query != "" {
+		opts.SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}})
 	}
 
 	curr, err := br.collection.Find(ctx, filter, opts)
 	if err != nil {
-		// No need for log.Fatal, just return the error
 		return nil, 0, fmt.Errorf("failed to find blogs: %w", err)
 	}
 	defer curr.Close(ctx)
 
-	if err := curr.All(ctx, &blogs); err != nil {
+	var results []scoredBlog
+	if err := curr.All(ctx, &results); err != nil {
 		return nil, 0, err
 	}
+	blogs := scoredBlogs(results, params.Query != "")
 
 	total, err := br.collection.CountDocuments(ctx, filter)
 	if err != nil {
@@ -101,16 +276,381 @@ func (br *BlogRepo) GetAll(page, limit int, sort string) ([]*domain.Blog, int64,
 	return blogs, total, nil
 }
 
-// CORRECTED: This logic is now simple and correct.
+// the page, the total and the facets come from a single $facet aggregation, so they
+// all see the same snapshot of the collection
+func (br *BlogRepo) ListWithFacets(params *domain.ListBlogParams) ([]*domain.Blog, int64, *domain.BlogFacets, error) {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 10*time.Second)
+	defer cancel()
+
+	sort := listSort(params.SortBy)
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: listFilter(params)}}}
+	if params.Query != "" {
+		// the text score is not carried into the $facet branches, so keep it as a field
+		pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: bson.M{"score": bson.M{"$meta": "textScore"}}}})
+		if params.SortBy == domain.BlogSortRelevance {
+			sort = bson.D{{Key: "score", Value: -1}, {Key: "_id", Value: -1}}
+		}
+	}
+	countBy := func(field interface{}, order bson.D) bson.A {
+		return bson.A{
+			bson.M{"$group": bson.M{"_id": field, "count": bson.M{"$sum": 1}}},
+			bson.M{"$sort": order},
+			bson.M{"$limit": domain.BlogFacetSize},
+		}
+	}
+	mostUsed := bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}
+	pipeline = append(pipeline, bson.D{{Key: "$facet", Value: bson.M{
+		"blogs": bson.A{
+			bson.M{"$sort": sort},
+			bson.M{"$skip": int64(params.Page-1) * int64(params.Limit)},
+			bson.M{"$limit": params.Limit},
+		},
+		"total":   bson.A{bson.M{"$count": "count"}},
+		"tags":    append(bson.A{bson.M{"$unwind": "$tags"}}, countBy("$tags", mostUsed)...),
+		"authors": countBy("$author_username", mostUsed),
+		"months": countBy(
+			bson.M{"$dateToString": bson.M{"format": "%Y-%m", "date": "$created_at"}},
+			bson.D{{Key: "_id", Value: -1}},
+		),
+	}}})
+
+	curr, err := br.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("failed to list blogs with facets: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	type bucket struct {
+		Term  string `bson:"_id"`
+		Count int    `bson:"count"`
+	}
+	type facetResult struct {
+		Blogs []scoredBlog `bson:"blogs"`
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+		Tags    []bucket `bson:"tags"`
+		Authors []bucket `bson:"authors"`
+		Months  []bucket `bson:"months"`
+	}
+	var results []facetResult
+	if err := curr.All(ctx, &results); err != nil {
+		return nil, 0, nil, err
+	}
+	// $facet always yields one document, even when nothing matched
+	var result facetResult
+	if len(results) > 0 {
+		result = results[0]
+	}
+
+	var total int64
+	if len(result.Total) > 0 {
+		total = result.Total[0].Count
+	}
+	counts := func(buckets []bucket) []domain.FacetCount {
+		facet := make([]domain.FacetCount, 0, len(buckets))
+		for _, b := range buckets {
+			facet = append(facet, domain.FacetCount{Term: b.Term, Count: b.Count})
+		}
+		return facet
+	}
+	facets := &domain.BlogFacets{
+		Tags:    counts(result.Tags),
+		Authors: counts(result.Authors),
+		Months:  counts(result.Months),
+	}
+	return scoredBlogs(result.Blogs, params.Query != ""), total, facets, nil
+}
+
+// reads the page after (or before) the cursor, or the first page for a nil cursor. Pages
+// are found through the sort index instead of skipping, and only counted on request.
+func (br *BlogRepo) ListByCursor(params *domain.ListBlogParams, after *domain.Cursor, count bool) ([]*domain.Blog, *domain.CursorPage, error) {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
+	defer cancel()
+
+	filter := listFilter(params)
+	sort := listSort(params.SortBy)
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: filter}}}
+	if params.Query != "" {
+		// a cursor can only be compared with a field, not with the text score itself
+		pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: bson.M{"score": bson.M{"$meta": "textScore"}}}})
+		if params.SortBy == domain.BlogSortRelevance {
+			sort = bson.D{{Key: "score", Value: -1}, {Key: "_id", Value: -1}}
+		}
+	}
+	if after != nil {
+		keyset, err := keysetFilter(sort, after)
+		if err != nil {
+			return nil, nil, err
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: keyset}})
+		if after.Backward {
+			sort = reverseSort(sort)
+		}
+	}
+	// one extra blog tells whether there is another page
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: sort}},
+		bson.D{{Key: "$limit", Value: params.Limit + 1}},
+	)
+
+	curr, err := br.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find blogs: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	var results []scoredBlog
+	if err := curr.All(ctx, &results); err != nil {
+		return nil, nil, err
+	}
+	more := len(results) > params.Limit
+	if more {
+		results = results[:params.Limit]
+	}
+	if after != nil && after.Backward {
+		slices.Reverse(results)
+	}
+
+	var first, last *domain.Cursor
+	if len(results) > 0 {
+		first = blogCursor(&results[0], params.SortBy)
+		last = blogCursor(&results[len(results)-1], params.SortBy)
+	}
+	page := cursorPage(after, more, first, last)
+	if count {
+		total, err := br.collection.CountDocuments(ctx, filter)
+		if err != nil {
+			return nil, nil, err
+		}
+		page.Total = &total
+	}
+	return scoredBlogs(results, params.Query != ""), page, nil
+}
+
+// cursor at a blog, holding the values of the fields listSort orders by
+func blogCursor(blog *scoredBlog, sortBy string) *domain.Cursor {
+	var key []interface{}
+	switch sortBy {
+	case domain.BlogSortRelevance:
+		key = []interface{}{blog.Score}
+	case domain.BlogSortPopular:
+		key = []interface{}{blog.LikeCount, blog.CreatedAt}
+	case domain.BlogSortViews:
+		key = []interface{}{blog.ViewCount, blog.CreatedAt}
+	case domain.BlogSortComments:
+		key = []interface{}{blog.CommentCount, blog.CreatedAt}
+	default:
+		key = []interface{}{blog.CreatedAt}
+	}
+	return &domain.Cursor{Sort: sortBy, Key: key, ID: blog.ID}
+}
+
+// a listed blog with its full-text score, when the listing searched
+type scoredBlog struct {
+	domain.Blog `bson:",inline"`
+	Score       float64 `bson:"score,omitempty"`
+}
+
+func scoredBlogs(results []scoredBlog, searched bool) []*domain.Blog {
+	blogs := make([]*domain.Blog, 0, len(results))
+	for i := range results {
+		blog := &results[i].Blog
+		if searched {
+			blog.Search = &domain.SearchMatch{Score: results[i].Score}
+		}
+		blogs = append(blogs, blog)
+	}
+	return blogs
+}
+
+func (br *BlogRepo) ListAfterID(after primitive.ObjectID, limit int) ([]*domain.Blog, error) {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
+	defer cancel()
+
+	blogs := []*domain.Blog{}
+	filter := bson.M{"_id": bson.M{"$gt": after}, "deleted_at": nil}
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(int64(limit))
+
+	curr, err := br.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find blogs: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	if err := curr.All(ctx, &blogs); err != nil {
+		return nil, err
+	}
+	return blogs, nil
+}
+
+func (br *BlogRepo) ListUnrendered(after primitive.ObjectID, limit int) ([]*domain.Blog, error) {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
+	defer cancel()
+
+	blogs := []*domain.Blog{}
+	filter := bson.M{"_id": bson.M{"$gt": after}, "content_html": bson.M{"$exists": false}}
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(int64(limit))
+
+	curr, err := br.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find blogs: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	if err := curr.All(ctx, &blogs); err != nil {
+		return nil, err
+	}
+	return blogs, nil
+}
+
+func (br *BlogRepo) SetRendered(blog *domain.Blog) error {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{
+		"content_html": blog.ContentHTML,
+		"excerpt":      blog.Excerpt,
+		"word_count":   blog.WordCount,
+		"reading_time": blog.ReadingTime,
+		"toc":          blog.TOC,
+		"mentions":     blog.Mentions,
+	}}
+	result, err := br.collection.UpdateOne(ctx, bson.M{"_id": blog.ID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to store rendered blog: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("blog not found for update")
+	}
+	return nil
+}
+
+// every condition is ANDed, so the filters combine freely
+func listFilter(params *domain.ListBlogParams) bson.M {
+	and := bson.A{}
+
+	if params.Query != "" {
+		// $text understands "phrases" and -exclusions itself and, unlike a regex on
+		// user input, is served by the text index
+		and = append(and, bson.M{"$text": bson.M{"$search": params.Query}})
+	}
+
+	switch params.Status {
+	case domain.BlogStatusDeleted:
+		and = append(and, bson.M{"deleted_at": bson.M{"$ne": nil}})
+	case domain.BlogStatusAll:
+	default:
+		// soft deleted blogs stay in the collection until purged
+		and = append(and, bson.M{"deleted_at": nil})
+	}
+
+	if len(params.Tags) > 0 {
+		// a tag also matches blogs still carrying one of its synonyms
+		alternatives := func(tag string) []string {
+			return append([]string{tag}, params.TagSynonyms[tag]...)
+		}
+		if params.TagMatch == domain.TagMatchAll {
+			for _, tag := range params.Tags {
+				and = append(and, bson.M{"tags": bson.M{"$in": alternatives(tag)}})
+			}
+		} else {
+			var tags []string
+			for _, tag := range params.Tags {
+				tags = append(tags, alternatives(tag)...)
+			}
+			and = append(and, bson.M{"tags": bson.M{"$in": tags}})
+		}
+	}
+
+	if params.Author != "" {
+		// co-authors are listed alongside the posts they own
+		and = append(and, bson.M{"$or": bson.A{
+			bson.M{"author_username": params.Author},
+			bson.M{"co_authors": params.Author},
+		}})
+	}
+	if params.OwnerID != nil {
+		and = append(and, bson.M{"author_id": *params.OwnerID})
+	}
+
+	created := bson.M{}
+	if params.From != nil {
+		created["$gte"] = *params.From
+	}
+	if params.To != nil {
+		created["$lt"] = *params.To
+	}
+	if len(created) > 0 {
+		and = append(and, bson.M{"created_at": created})
+	}
+
+	if len(and) == 0 {
+		return bson.M{}
+	}
+	return bson.M{"$and": and}
+}
+
+// _id breaks ties so pages do not overlap
+func listSort(sortBy string) bson.D {
+	switch sortBy {
+	case domain.BlogSortRelevance:
+		return bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "_id", Value: -1}}
+	case domain.BlogSortOldest:
+		return bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}
+	case domain.BlogSortPopular:
+		return bson.D{{Key: "like_count", Value: -1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}
+	case domain.BlogSortViews:
+		return bson.D{{Key: "view_count", Value: -1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}
+	case domain.BlogSortComments:
+		return bson.D{{Key: "comment_count", Value: -1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}
+	}
+	return bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}
+}
+
+// stores the fields an editor owns; counters, collaborators and the comment mode are
+// changed atomically elsewhere and must not be overwritten with the copy read before the edit
 func (br *BlogRepo) Update(blog *domain.Blog) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
 	defer cancel()
 
-	filter := bson.M{"_id": blog.ID}
-	update := bson.M{"$set": blog}
+	filter := bson.M{"_id": blog.ID, "deleted_at": nil}
+	set := bson.M{
+		"title":          blog.Title,
+		"slug":           blog.Slug,
+		"slug_history":   blog.SlugHistory,
+		"content":        blog.Content,
+		"content_html":   blog.ContentHTML,
+		"excerpt":        blog.Excerpt,
+		"word_count":     blog.WordCount,
+		"reading_time":   blog.ReadingTime,
+		"toc":            blog.TOC,
+		"mentions":       blog.Mentions,
+		"notified_users": blog.NotifiedUsers,
+		"updated_at":     blog.UpdatedAt,
+	}
+	// tags and category are omitempty on the blog, so clearing them removes the field
+	unset := bson.M{}
+	if len(blog.Tags) == 0 {
+		unset["tags"] = ""
+	} else {
+		set["tags"] = blog.Tags
+	}
+	if blog.CategoryID == nil {
+		unset["category_id"] = ""
+	} else {
+		set["category_id"] = blog.CategoryID
+	}
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
 
 	result, err := br.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("blog with this slug already exists: %w", err)
+		}
 		return fmt.Errorf("failed to update blog: %w", err)
 	}
 
@@ -121,27 +661,136 @@ func (br *BlogRepo) Update(blog *domain.Blog) error {
 	return nil
 }
 
+// moves a blog to the trash; it is purged for good once it expires, see PurgeTrash
 func (br *BlogRepo) Delete(id primitive.ObjectID) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
 	defer cancel()
 
-	result, err := br.collection.DeleteOne(ctx, bson.D{{Key: "_id", Value: id}})
+	filter := bson.M{"_id": id, "deleted_at": nil}
+	update := bson.M{"$set": bson.M{"deleted_at": time.Now()}}
+
+	result, err := br.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
 	}
-	if result.DeletedCount == 0 {
+	if result.MatchedCount == 0 {
 		return errors.New("blog not found for delete")
 	}
 	return nil
 }
 
+func (br *BlogRepo) GetDeletedByID(id primitive.ObjectID) (*domain.Blog, error) {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
+	defer cancel()
+
+	var blog domain.Blog
+	filter := bson.M{"_id": id, "deleted_at": bson.M{"$ne": nil}}
+
+	err := br.collection.FindOne(ctx, filter).Decode(&blog)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("blog not found in trash")
+		}
+		return nil, fmt.Errorf("database error in GetDeletedByID: %w", err)
+	}
+	return &blog, nil
+}
+
+// takes a blog back out of the trash
+func (br *BlogRepo) Restore(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": id, "deleted_at": bson.M{"$ne": nil}}
+	update := bson.M{"$unset": bson.M{"deleted_at": ""}}
+
+	result, err := br.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to restore blog: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("blog not found in trash")
+	}
+	return nil
+}
+
+// lists the trashed blogs of an author, most recently deleted first
+func (br *BlogRepo) GetTrash(authorID primitive.ObjectID, page, limit int) ([]*domain.Blog, int64, error) {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
+	defer cancel()
+
+	var blogs []*domain.Blog
+	filter := bson.M{"author_id": authorID, "deleted_at": bson.M{"$ne": nil}}
+
+	opts := options.Find()
+	opts.SetSort(bson.D{{Key: "deleted_at", Value: -1}})
+	opts.SetLimit(int64(limit))
+	opts.SetSkip(int64(page-1) * int64(limit))
+
+	curr, err := br.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find trashed blogs: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	if err := curr.All(ctx, &blogs); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := br.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return blogs, total, nil
+}
+
+func (br *BlogRepo) ListDeletedBefore(cutoff time.Time) ([]primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 30*time.Second)
+	defer cancel()
+
+	filter := bson.M{"deleted_at": bson.M{"$lt": cutoff}}
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+
+	curr, err := br.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expired trash: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	var expired []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := curr.All(ctx, &expired); err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(expired))
+	for _, e := range expired {
+		ids = append(ids, e.ID)
+	}
+	return ids, nil
+}
+
+// only removes the blog while it is still in the trash
+func (br *BlogRepo) Purge(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
+	defer cancel()
+
+	_, err := br.collection.DeleteOne(ctx, bson.M{"_id": id, "deleted_at": bson.M{"$ne": nil}})
+	if err != nil {
+		return fmt.Errorf("failed to purge blog: %w", err)
+	}
+	return nil
+}
+
 func (br *BlogRepo) SearchByTitle(title string, page, limit int) ([]*domain.Blog, int64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
 	defer cancel()
 
 	var blogs []*domain.Blog
-	// Note: For best results, a text index should be created on this field in MongoDB.
-	filter := bson.M{"title": bson.M{"$regex": title, "$options": "i"}} // Case-insensitive substring search
+	// the input is matched literally; full-text search goes through List with a query
+	filter := bson.M{"title": bson.M{"$regex": regexp.QuoteMeta(title), "$options": "i"}, "deleted_at": nil} // Case-insensitive substring search
 
 	// Re-using a helper for paginated queries would be ideal, but for now this is fine.
 	opts := options.Find()
@@ -167,12 +816,19 @@ func (br *BlogRepo) SearchByTitle(title string, page, limit int) ([]*domain.Blog
 }
 
 func (br *BlogRepo) SearchByAuthor(author string, page, limit int) ([]*domain.Blog, int64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
 	defer cancel()
 
 	var blogs []*domain.Blog
 	// CORRECTED: The field name must match the schema exactly.
-	filter := bson.M{"author_username": author}
+	// co-authors are listed alongside the posts they own
+	filter := bson.M{
+		"$or": bson.A{
+			bson.M{"author_username": author},
+			bson.M{"co_authors": author},
+		},
+		"deleted_at": nil,
+	}
 
 	opts := options.Find()
 	opts.SetLimit(int64(limit))
@@ -196,53 +852,398 @@ func (br *BlogRepo) SearchByAuthor(author string, page, limit int) ([]*domain.Bl
 	return blogs, total, nil
 }
 
-// --- ADDED STUB IMPLEMENTATIONS FOR ALL MISSING METHODS ---
-// These are required for the code to compile. They return empty data.
+// atomically counts a view; views of trashed blogs are not counted
+func (br *BlogRepo) IncrementViewCount(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
+	defer cancel()
 
-func (br *BlogRepo) FilterByTags(tags []string, page, limit int) ([]*domain.Blog, int64, error) {
-	return []*domain.Blog{}, 0, nil
+	result, err := br.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "deleted_at": nil},
+		bson.M{"$inc": bson.M{"view_count": 1}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to increment view count: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("blog not found")
+	}
+	return nil
 }
 
-func (br *BlogRepo) FilterByDate(startDate, endDate time.Time, page, limit int) ([]*domain.Blog, int64, error) {
-	return []*domain.Blog{}, 0, nil
-}
+func (br *BlogRepo) UpdateCommentMode(blogID primitive.ObjectID, mode string) error {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
+	defer cancel()
 
-func (br *BlogRepo) GetPopular(limit int) ([]*domain.Blog, error) {
-	return []*domain.Blog{}, nil
+	result, err := br.collection.UpdateOne(ctx,
+		bson.M{"_id": blogID, "deleted_at": nil},
+		bson.M{"$set": bson.M{"comment_mode": mode}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update comment mode: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("blog not found")
+	}
+	return nil
 }
 
-func (br *BlogRepo) IncrementViewCount(id primitive.ObjectID) error {
+// atomically adjusts the denormalized comment counter
+func (br *BlogRepo) IncrementCommentCount(blogID primitive.ObjectID, delta int) error {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
+	defer cancel()
+
+	result, err := br.collection.UpdateOne(ctx,
+		bson.M{"_id": blogID},
+		bson.M{"$inc": bson.M{"comment_count": delta}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update comment count: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("blog not found")
+	}
 	return nil
 }
 
-func (br *BlogRepo) AddComment(blogID primitive.ObjectID, comment *domain.Comment) error {
-	return nil
+// atomically adjusts the denormalized reaction counters, like_count included, and returns
+// the counters as they are after the update
+func (br *BlogRepo) IncrementReactionCounts(blogID primitive.ObjectID, deltas map[string]int) (map[string]int, error) {
+	inc := bson.M{}
+	for reactionType, delta := range deltas {
+		if delta == 0 {
+			continue
+		}
+		inc["reaction_counts."+reactionType] = delta
+		if reactionType == domain.ReactionLike {
+			inc["like_count"] = delta
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
+	defer cancel()
+
+	var blog domain.Blog
+	var err error
+	opts := options.FindOne().SetProjection(bson.M{"reaction_counts": 1})
+	if len(inc) == 0 {
+		err = br.collection.FindOne(ctx, bson.M{"_id": blogID}, opts).Decode(&blog)
+	} else {
+		err = br.collection.FindOneAndUpdate(ctx,
+			bson.M{"_id": blogID},
+			bson.M{"$inc": inc},
+			options.FindOneAndUpdate().
+				SetProjection(bson.M{"reaction_counts": 1}).
+				SetReturnDocument(options.After),
+		).Decode(&blog)
+	}
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("blog not found")
+		}
+		return nil, fmt.Errorf("failed to update reaction counts: %w", err)
+	}
+	return blog.ReactionCounts, nil
 }
 
-func (br *BlogRepo) DeleteComment(blogID, commentID primitive.ObjectID) error {
-	return nil
+func (br *BlogRepo) ListByCategories(categoryIDs []primitive.ObjectID, page, limit int) ([]*domain.Blog, int64, error) {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
+	defer cancel()
+
+	blogs := []*domain.Blog{}
+	filter := bson.M{"category_id": bson.M{"$in": categoryIDs}, "deleted_at": nil}
+
+	opts := options.Find()
+	opts.SetSort(bson.D{{Key: "created_at", Value: -1}})
+	opts.SetLimit(int64(limit))
+	opts.SetSkip(int64(page-1) * int64(limit))
+
+	curr, err := br.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find blogs: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	if err := curr.All(ctx, &blogs); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := br.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return blogs, total, nil
 }
 
-func (br *BlogRepo) UpdateComment(blogID, commentID primitive.ObjectID, content string) error {
-	return nil
+// trashed blogs included, so they do not come back pointing at a missing category
+func (br *BlogRepo) ClearCategory(categoryID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 30*time.Second)
+	defer cancel()
+
+	filter := bson.M{"category_id": categoryID}
+	ids, err := br.findIDs(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	_, err = br.collection.UpdateMany(ctx, filter, bson.M{"$unset": bson.M{"category_id": ""}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear category: %w", err)
+	}
+	return ids, nil
 }
 
-func (br *BlogRepo) AddLike(blogID primitive.ObjectID, userID string) error {
-	return nil
+// renames a tag on every blog, trashed ones included so they come back with the new name.
+// Blogs that already carry the new tag just lose the old one.
+func (br *BlogRepo) ReplaceTag(from, to string) ([]primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 30*time.Second)
+	defer cancel()
+
+	ids, err := br.findIDs(ctx, bson.M{"tags": from})
+	if err != nil {
+		return nil, err
+	}
+	_, err = br.collection.UpdateMany(ctx,
+		bson.M{"tags": from, "$nor": bson.A{bson.M{"tags": to}}},
+		bson.M{"$set": bson.M{"tags.$[tag]": to}},
+		options.Update().SetArrayFilters(options.ArrayFilters{Filters: []interface{}{bson.M{"tag": from}}}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rename tag: %w", err)
+	}
+	_, err = br.collection.UpdateMany(ctx, bson.M{"tags": from}, bson.M{"$pull": bson.M{"tags": from}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to rename tag: %w", err)
+	}
+	return ids, nil
+}
+
+// IDs of the blogs matching filter, found before a bulk update so its events can name them
+func (br *BlogRepo) findIDs(ctx context.Context, filter bson.M) ([]primitive.ObjectID, error) {
+	curr, err := br.collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find blogs: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	var blogs []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := curr.All(ctx, &blogs); err != nil {
+		return nil, err
+	}
+	ids := make([]primitive.ObjectID, 0, len(blogs))
+	for _, blog := range blogs {
+		ids = append(ids, blog.ID)
+	}
+	return ids, nil
+}
+
+func (br *BlogRepo) CountByTag(name string) (int64, error) {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
+	defer cancel()
+
+	return br.collection.CountDocuments(ctx, bson.M{"tags": name, "deleted_at": nil})
+}
+
+// counts how many blogs outside the trash use each tag
+func (br *BlogRepo) TagUsage() (map[string]int64, error) {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 30*time.Second)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"deleted_at": nil, "tags.0": bson.M{"$exists": true}}}},
+		{{Key: "$unwind", Value: "$tags"}},
+		{{Key: "$group", Value: bson.M{"_id": "$tags", "count": bson.M{"$sum": 1}}}},
+	}
+	curr, err := br.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tag usage: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	var results []struct {
+		Name  string `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+	if err := curr.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	usage := make(map[string]int64, len(results))
+	for _, result := range results {
+		usage[result.Name] = result.Count
+	}
+	return usage, nil
+}
+
+// scores the blogs outside the trash written since the given time (all of them for nil)
+// and returns the best limit of them, best first. A blog's score is its weighted
+// engagement, halved for every half-life of its age at now.
+func (br *BlogRepo) TrendingScores(since *time.Time, now time.Time, weights domain.TrendingWeights, limit int) ([]domain.TrendingEntry, error) {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 30*time.Second)
+	defer cancel()
+
+	match := bson.M{"deleted_at": nil}
+	if since != nil {
+		match["created_at"] = bson.M{"$gte": *since}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$project", Value: bson.M{"_id": 0, "blog_id": "$_id", "score": trendingScore(weights, now)}}},
+		{{Key: "$sort", Value: bson.D{{Key: "score", Value: -1}, {Key: "blog_id", Value: -1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+	curr, err := br.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to score trending blogs: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	entries := []domain.TrendingEntry{}
+	if err := curr.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
 }
 
-func (br *BlogRepo) RemoveLike(blogID primitive.ObjectID, userID string) error {
+// aggregation expression for the trending score of a blog as of now
+func trendingScore(weights domain.TrendingWeights, now time.Time) interface{} {
+	weighted := func(weight float64, field string) bson.M {
+		return bson.M{"$multiply": bson.A{weight, bson.M{"$ifNull": bson.A{field, 0}}}}
+	}
+	var score interface{} = bson.M{"$add": bson.A{
+		weighted(weights.Views, "$view_count"),
+		weighted(weights.Likes, "$like_count"),
+		weighted(-weights.Dislikes, "$reaction_counts."+domain.ReactionDislike),
+		weighted(weights.Comments, "$comment_count"),
+	}}
+	if weights.HalfLife > 0 {
+		// exp(-ln 2 * age / half-life), with the age in milliseconds like date arithmetic
+		age := bson.M{"$max": bson.A{0, bson.M{"$subtract": bson.A{now, "$created_at"}}}}
+		rate := -math.Ln2 / float64(weights.HalfLife.Milliseconds())
+		score = bson.M{"$multiply": bson.A{score, bson.M{"$exp": bson.M{"$multiply": bson.A{rate, age}}}}}
+	}
+	return score
+}
+
+// invites a collaborator unless the user is already on the blog
+func (br *BlogRepo) AddCollaborator(blogID primitive.ObjectID, collaborator *domain.Collaborator) error {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"_id":                   blogID,
+		"deleted_at":            nil,
+		"collaborators.user_id": bson.M{"$ne": collaborator.UserID},
+	}
+	update := bson.M{"$push": bson.M{"collaborators": collaborator}}
+
+	result, err := br.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to add collaborator: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("invalid invitation: user is already a collaborator")
+	}
 	return nil
 }
 
-func (br *BlogRepo) AddDislike(blogID primitive.ObjectID, userID string) error {
+// marks a pending invitation as accepted and adds the user to the byline
+func (br *BlogRepo) AcceptCollaborator(blogID, userID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
+	defer cancel()
+
+	var blog domain.Blog
+	filter := bson.M{
+		"_id":        blogID,
+		"deleted_at": nil,
+		"collaborators": bson.M{"$elemMatch": bson.M{
+			"user_id": userID,
+			"status":  domain.CollaboratorPending,
+		}},
+	}
+	if err := br.collection.FindOne(ctx, filter).Decode(&blog); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return errors.New("invitation not found")
+		}
+		return fmt.Errorf("database error in AcceptCollaborator: %w", err)
+	}
+
+	var username string
+	for _, c := range blog.Collaborators {
+		if c.UserID == userID {
+			username = c.Username
+		}
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"collaborators.$.status":      domain.CollaboratorAccepted,
+			"collaborators.$.accepted_at": time.Now(),
+		},
+		"$addToSet": bson.M{"co_authors": username},
+	}
+	result, err := br.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to accept invitation: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("invitation not found")
+	}
 	return nil
 }
 
-func (br *BlogRepo) RemoveDislike(blogID primitive.ObjectID, userID string) error {
+// removes a collaborator, pending or accepted, along with their byline entry
+func (br *BlogRepo) RemoveCollaborator(blogID, userID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
+	defer cancel()
+
+	var blog domain.Blog
+	filter := bson.M{"_id": blogID, "collaborators.user_id": userID}
+	if err := br.collection.FindOne(ctx, filter).Decode(&blog); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return errors.New("collaborator not found")
+		}
+		return fmt.Errorf("database error in RemoveCollaborator: %w", err)
+	}
+
+	update := bson.M{"$pull": bson.M{"collaborators": bson.M{"user_id": userID}}}
+	for _, c := range blog.Collaborators {
+		if c.UserID == userID {
+			update["$pull"].(bson.M)["co_authors"] = c.Username
+		}
+	}
+
+	if _, err := br.collection.UpdateOne(ctx, filter, update); err != nil {
+		return fmt.Errorf("failed to remove collaborator: %w", err)
+	}
 	return nil
 }
 
-func (br *BlogRepo) GetTagIDByName(name string) (primitive.ObjectID, error) {
-	return primitive.NilObjectID, nil
+// lists the blogs where the user is a collaborator with the given status
+func (br *BlogRepo) GetByCollaborator(userID primitive.ObjectID, status string) ([]*domain.Blog, error) {
+	ctx, cancel := context.WithTimeout(baseContext(br.ctx), 5*time.Second)
+	defer cancel()
+
+	blogs := []*domain.Blog{}
+	filter := bson.M{
+		"deleted_at": nil,
+		"collaborators": bson.M{"$elemMatch": bson.M{
+			"user_id": userID,
+			"status":  status,
+		}},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}})
+
+	curr, err := br.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find blogs: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	if err := curr.All(ctx, &blogs); err != nil {
+		return nil, err
+	}
+	return blogs, nil
 }
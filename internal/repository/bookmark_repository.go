@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type BookmarkRepository struct {
+	db         *database.MongoDB
+	collection *mongo.Collection
+}
+
+func NewBookmarkRepository(db *database.MongoDB) domain.BookmarkRepository {
+	collection := db.GetCollection("bookmarks")
+
+	indexModels := []mongo.IndexModel{
+		{
+			// a blog appears at most once in the bookmarks and in each reading list
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "list_id", Value: 1}, {Key: "blog_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "list_id", Value: 1}, {Key: "position", Value: 1}, {Key: "_id", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "blog_id", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "list_id", Value: 1}},
+		},
+	}
+	// indexes might already exist, so an error here is not fatal
+	_, _ = collection.Indexes().CreateMany(context.Background(), indexModels)
+
+	return &BookmarkRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+func (r *BookmarkRepository) Add(bookmark *domain.Bookmark) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.InsertOne(ctx, bookmark)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return errors.New("blog is already saved")
+		}
+		return fmt.Errorf("failed to save bookmark: %w", err)
+	}
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		bookmark.ID = oid
+	}
+	return nil
+}
+
+func (r *BookmarkRepository) Remove(userID primitive.ObjectID, listID *primitive.ObjectID, blogID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"user_id": userID, "list_id": listID, "blog_id": blogID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errors.New("bookmark not found")
+	}
+	return nil
+}
+
+// bookmark joined with its blog, as read by List
+type savedBlog struct {
+	domain.Bookmark `bson:",inline"`
+	SavedBlog       domain.Blog `bson:"blog"`
+}
+
+func (r *BookmarkRepository) List(userID primitive.ObjectID, listID *primitive.ObjectID, after *domain.Cursor, limit int, count bool) ([]*domain.Bookmark, *domain.CursorPage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"user_id": userID, "list_id": listID}
+	order := bson.D{{Key: "position", Value: 1}, {Key: "_id", Value: 1}}
+	query := filter
+	if after != nil {
+		keyset, err := keysetFilter(order, after)
+		if err != nil {
+			return nil, nil, err
+		}
+		query = bson.M{"$and": bson.A{filter, keyset}}
+		if after.Backward {
+			order = reverseSort(order)
+		}
+	}
+	// one extra bookmark tells whether there is another page
+	pipeline := append(mongo.Pipeline{
+		{{Key: "$match", Value: query}},
+		{{Key: "$sort", Value: order}},
+	}, liveBlogStages()...)
+	pipeline = append(pipeline, bson.D{{Key: "$limit", Value: limit + 1}})
+
+	curr, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find bookmarks: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	var results []savedBlog
+	if err := curr.All(ctx, &results); err != nil {
+		return nil, nil, err
+	}
+	more := len(results) > limit
+	if more {
+		results = results[:limit]
+	}
+	if after != nil && after.Backward {
+		slices.Reverse(results)
+	}
+
+	bookmarks := make([]*domain.Bookmark, 0, len(results))
+	for i := range results {
+		bookmark := results[i].Bookmark
+		bookmark.Blog = &results[i].SavedBlog
+		bookmarks = append(bookmarks, &bookmark)
+	}
+	var first, last *domain.Cursor
+	if len(bookmarks) > 0 {
+		first = bookmarkCursor(bookmarks[0])
+		last = bookmarkCursor(bookmarks[len(bookmarks)-1])
+	}
+	page := cursorPage(after, more, first, last)
+	if count {
+		total, err := r.count(ctx, filter)
+		if err != nil {
+			return nil, nil, err
+		}
+		page.Total = &total
+	}
+	return bookmarks, page, nil
+}
+
+// attach each bookmark's blog and drop the bookmarks of blogs in the trash or purged
+func liveBlogStages() []bson.D {
+	return []bson.D{
+		{{Key: "$lookup", Value: bson.M{"from": "blogs", "localField": "blog_id", "foreignField": "_id", "as": "blog"}}},
+		{{Key: "$unwind", Value: "$blog"}},
+		{{Key: "$match", Value: bson.M{"blog.deleted_at": nil}}},
+	}
+}
+
+// the bookmarks matching filter whose blogs are still around
+func (r *BookmarkRepository) count(ctx context.Context, filter bson.M) (int64, error) {
+	pipeline := append(mongo.Pipeline{{{Key: "$match", Value: filter}}}, liveBlogStages()...)
+	pipeline = append(pipeline, bson.D{{Key: "$count", Value: "total"}})
+
+	curr, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count bookmarks: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	var counted []struct {
+		Total int64 `bson:"total"`
+	}
+	if err := curr.All(ctx, &counted); err != nil {
+		return 0, err
+	}
+	if len(counted) == 0 {
+		return 0, nil
+	}
+	return counted[0].Total, nil
+}
+
+func bookmarkCursor(bookmark *domain.Bookmark) *domain.Cursor {
+	return &domain.Cursor{Sort: domain.BookmarkSortPosition, Key: []interface{}{bookmark.Position}, ID: bookmark.ID}
+}
+
+func (r *BookmarkRepository) ListBlogIDs(userID primitive.ObjectID, listID *primitive.ObjectID) ([]primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "position", Value: 1}, {Key: "_id", Value: 1}}).
+		SetProjection(bson.M{"blog_id": 1})
+	curr, err := r.collection.Find(ctx, bson.M{"user_id": userID, "list_id": listID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find bookmarks: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	var bookmarks []domain.Bookmark
+	if err := curr.All(ctx, &bookmarks); err != nil {
+		return nil, err
+	}
+	ids := make([]primitive.ObjectID, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		ids = append(ids, b.BlogID)
+	}
+	return ids, nil
+}
+
+func (r *BookmarkRepository) SetOrder(userID primitive.ObjectID, listID *primitive.ObjectID, blogIDs []primitive.ObjectID) error {
+	if len(blogIDs) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	models := make([]mongo.WriteModel, 0, len(blogIDs))
+	for i, blogID := range blogIDs {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"user_id": userID, "list_id": listID, "blog_id": blogID}).
+			SetUpdate(bson.M{"$set": bson.M{"position": int64(i)}}))
+	}
+	if _, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to reorder bookmarks: %w", err)
+	}
+	return nil
+}
+
+func (r *BookmarkRepository) BookmarkedBlogIDs(userID primitive.ObjectID, blogIDs []primitive.ObjectID) (map[primitive.ObjectID]bool, error) {
+	bookmarked := map[primitive.ObjectID]bool{}
+	if len(blogIDs) == 0 {
+		return bookmarked, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ids, err := r.collection.Distinct(ctx, "blog_id", bson.M{"user_id": userID, "blog_id": bson.M{"$in": blogIDs}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find bookmarks: %w", err)
+	}
+	for _, id := range ids {
+		if oid, ok := id.(primitive.ObjectID); ok {
+			bookmarked[oid] = true
+		}
+	}
+	return bookmarked, nil
+}
+
+func (r *BookmarkRepository) DeleteByList(listID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(ctx, bson.M{"list_id": listID})
+	return err
+}
+
+// removes a blog from every bookmark list, used when the blog is purged
+func (r *BookmarkRepository) DeleteByBlog(blogID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(ctx, bson.M{"blog_id": blogID})
+	return err
+}
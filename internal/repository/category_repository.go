@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CategoryRepository struct {
+	db         *database.MongoDB
+	collection *mongo.Collection
+	ctx        context.Context // set by WithContext, e.g. to join a transaction
+}
+
+func NewCategoryRepository(db *database.MongoDB) domain.CategoryRepository {
+	collection := db.GetCollection("categories")
+
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "slug", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "parent_id", Value: 1}, {Key: "position", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "ancestors", Value: 1}},
+		},
+	}
+	// indexes might already exist, so an error here is not fatal
+	_, _ = collection.Indexes().CreateMany(context.Background(), indexModels)
+
+	return &CategoryRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+// returns a copy of the repository whose operations run within ctx
+func (r *CategoryRepository) WithContext(ctx context.Context) domain.CategoryRepository {
+	clone := *r
+	clone.ctx = ctx
+	return &clone
+}
+
+func (r *CategoryRepository) Create(category *domain.Category) error {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.InsertOne(ctx, category)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return errors.New("category already exists")
+		}
+		return fmt.Errorf("failed to create category: %w", err)
+	}
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		category.ID = oid
+	}
+	return nil
+}
+
+func (r *CategoryRepository) GetByID(id primitive.ObjectID) (*domain.Category, error) {
+	return r.findOne(bson.M{"_id": id})
+}
+
+func (r *CategoryRepository) GetBySlug(slug string) (*domain.Category, error) {
+	return r.findOne(bson.M{"slug": slug})
+}
+
+func (r *CategoryRepository) GetByIDs(ids []primitive.ObjectID) ([]*domain.Category, error) {
+	return r.find(bson.M{"_id": bson.M{"$in": ids}})
+}
+
+func (r *CategoryRepository) List() ([]*domain.Category, error) {
+	return r.find(bson.M{})
+}
+
+func (r *CategoryRepository) ListDescendants(id primitive.ObjectID) ([]*domain.Category, error) {
+	return r.find(bson.M{"ancestors": id})
+}
+
+func (r *CategoryRepository) HasChildren(id primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{"parent_id": id}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *CategoryRepository) Update(category *domain.Category) error {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": category.ID}, bson.M{"$set": category})
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return errors.New("category already exists")
+		}
+		return fmt.Errorf("failed to update category: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("category not found for update")
+	}
+	return nil
+}
+
+func (r *CategoryRepository) SetAncestors(id primitive.ObjectID, ancestors []primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"ancestors": ancestors}})
+	if err != nil {
+		return fmt.Errorf("failed to move category: %w", err)
+	}
+	return nil
+}
+
+func (r *CategoryRepository) Delete(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errors.New("category not found for delete")
+	}
+	return nil
+}
+
+func (r *CategoryRepository) findOne(filter bson.M) (*domain.Category, error) {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	var category domain.Category
+	err := r.collection.FindOne(ctx, filter).Decode(&category)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("category not found")
+		}
+		return nil, fmt.Errorf("database error in findOne: %w", err)
+	}
+	return &category, nil
+}
+
+// sorted in sibling order so trees built from the result need no further sorting
+func (r *CategoryRepository) find(filter bson.M) ([]*domain.Category, error) {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	categories := []*domain.Category{}
+	opts := options.Find().SetSort(bson.D{{Key: "position", Value: 1}, {Key: "name", Value: 1}})
+	curr, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find categories: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	if err := curr.All(ctx, &categories); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
@@ -0,0 +1,371 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CommentRepository struct {
+	db         *database.MongoDB
+	collection *mongo.Collection
+	ctx        context.Context // set by WithContext, e.g. to join a transaction
+}
+
+func NewCommentRepository(db *database.MongoDB) domain.CommentRepository {
+	collection := db.GetCollection("comments")
+
+	indexModels := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "blog_id", Value: 1}, {Key: "parent_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "blog_id", Value: 1}, {Key: "parent_id", Value: 1}, {Key: "reply_count", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "author_id", Value: 1}, {Key: "status", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "blog_author_id", Value: 1}, {Key: "created_at", Value: 1}},
+		},
+	}
+	// indexes might already exist, so an error here is not fatal
+	_, _ = collection.Indexes().CreateMany(context.Background(), indexModels)
+
+	return &CommentRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+// returns a copy of the repository whose operations run within ctx
+func (r *CommentRepository) WithContext(ctx context.Context) domain.CommentRepository {
+	clone := *r
+	clone.ctx = ctx
+	return &clone
+}
+
+func (r *CommentRepository) Create(comment *domain.Comment) error {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.InsertOne(ctx, comment)
+	if err != nil {
+		return fmt.Errorf("failed to create comment: %w", err)
+	}
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		comment.ID = oid
+	}
+	return nil
+}
+
+func (r *CommentRepository) GetByID(id primitive.ObjectID) (*domain.Comment, error) {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	var comment domain.Comment
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&comment)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("comment not found")
+		}
+		return nil, fmt.Errorf("database error in GetByID: %w", err)
+	}
+	return &comment, nil
+}
+
+// matches comments that are published, including ones created before moderation existed,
+// which have no status or an empty one; see Comment.IsApproved
+var approvedComment = bson.M{"status": bson.M{"$in": bson.A{domain.CommentApproved, "", nil}}}
+
+// lists one level of a comment thread: top level comments when parentID is nil, otherwise the replies to parentID.
+// Pending comments are only included for moderators and for their own author.
+func (r *CommentRepository) ListByBlog(blogID primitive.ObjectID, parentID *primitive.ObjectID, viewerID primitive.ObjectID, canModerate bool, sort string, page, limit int) ([]*domain.Comment, int64, error) {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	comments := []*domain.Comment{}
+	filter := threadFilter(blogID, parentID, viewerID, canModerate)
+
+	opts := options.Find()
+	opts.SetLimit(int64(limit))
+	opts.SetSkip(int64(page-1) * int64(limit))
+	opts.SetSort(threadSort(sort))
+
+	curr, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find comments: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	if err := curr.All(ctx, &comments); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return comments, total, nil
+}
+
+// like ListByBlog, reading the page after (or before) the cursor instead of a numbered
+// page; the comments are only counted on request
+func (r *CommentRepository) ListByBlogCursor(blogID primitive.ObjectID, parentID *primitive.ObjectID, viewerID primitive.ObjectID, canModerate bool, sort string, after *domain.Cursor, limit int, count bool) ([]*domain.Comment, *domain.CursorPage, error) {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	comments := []*domain.Comment{}
+	filter := threadFilter(blogID, parentID, viewerID, canModerate)
+	order := threadSort(sort)
+	query := filter
+	if after != nil {
+		keyset, err := keysetFilter(order, after)
+		if err != nil {
+			return nil, nil, err
+		}
+		query = bson.M{"$and": bson.A{filter, keyset}}
+		if after.Backward {
+			order = reverseSort(order)
+		}
+	}
+	// one extra comment tells whether there is another page
+	opts := options.Find().SetSort(order).SetLimit(int64(limit) + 1)
+
+	curr, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find comments: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	if err := curr.All(ctx, &comments); err != nil {
+		return nil, nil, err
+	}
+	more := len(comments) > limit
+	if more {
+		comments = comments[:limit]
+	}
+	if after != nil && after.Backward {
+		slices.Reverse(comments)
+	}
+
+	var first, last *domain.Cursor
+	if len(comments) > 0 {
+		first = commentCursor(comments[0], sort)
+		last = commentCursor(comments[len(comments)-1], sort)
+	}
+	page := cursorPage(after, more, first, last)
+	if count {
+		total, err := r.collection.CountDocuments(ctx, filter)
+		if err != nil {
+			return nil, nil, err
+		}
+		page.Total = &total
+	}
+	return comments, page, nil
+}
+
+// matches one level of a thread as the viewer may see it, see ListByBlog
+func threadFilter(blogID primitive.ObjectID, parentID *primitive.ObjectID, viewerID primitive.ObjectID, canModerate bool) bson.M {
+	filter := bson.M{"blog_id": blogID, "parent_id": parentID}
+	switch {
+	case canModerate:
+		filter["status"] = bson.M{"$in": bson.A{domain.CommentApproved, domain.CommentPending, "", nil}}
+	case !viewerID.IsZero():
+		filter["$or"] = bson.A{
+			approvedComment,
+			bson.M{"status": domain.CommentPending, "author_id": viewerID},
+		}
+	default:
+		filter["status"] = approvedComment["status"]
+	}
+	return filter
+}
+
+// _id breaks ties so pages do not overlap
+func threadSort(sort string) bson.D {
+	switch sort {
+	case domain.CommentSortOldest:
+		return bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}
+	case domain.CommentSortTop:
+		return bson.D{{Key: "reply_count", Value: -1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}
+	}
+	return bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}
+}
+
+// cursor at a comment, holding the values of the fields threadSort orders by
+func commentCursor(comment *domain.Comment, sort string) *domain.Cursor {
+	key := []interface{}{comment.CreatedAt}
+	if sort == domain.CommentSortTop {
+		key = []interface{}{comment.ReplyCount, comment.CreatedAt}
+	}
+	return &domain.Cursor{Sort: sort, Key: key, ID: comment.ID}
+}
+
+// lists comments awaiting moderation, optionally only those on one author's blogs
+func (r *CommentRepository) ListPending(blogAuthorID *primitive.ObjectID, page, limit int) ([]*domain.Comment, int64, error) {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	comments := []*domain.Comment{}
+	filter := bson.M{"status": domain.CommentPending}
+	if blogAuthorID != nil {
+		filter["blog_author_id"] = *blogAuthorID
+	}
+
+	opts := options.Find()
+	opts.SetSort(bson.D{{Key: "created_at", Value: 1}})
+	opts.SetLimit(int64(limit))
+	opts.SetSkip(int64(page-1) * int64(limit))
+
+	curr, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find pending comments: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	if err := curr.All(ctx, &comments); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return comments, total, nil
+}
+
+// atomically moves a comment to a new moderation state and returns it as it was before
+func (r *CommentRepository) SetStatus(id primitive.ObjectID, status string, moderatorID primitive.ObjectID) (*domain.Comment, error) {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	var previous domain.Comment
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{"_id": id, "deleted": bson.M{"$ne": true}},
+		bson.M{"$set": bson.M{
+			"status":       status,
+			"moderated_by": moderatorID,
+			"moderated_at": time.Now(),
+		}},
+		options.FindOneAndUpdate().SetReturnDocument(options.Before),
+	).Decode(&previous)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("comment not found")
+		}
+		return nil, fmt.Errorf("failed to moderate comment: %w", err)
+	}
+	return &previous, nil
+}
+
+// reports whether the user has had at least one comment published
+func (r *CommentRepository) HasApproved(authorID primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"author_id": authorID, "status": approvedComment["status"]}
+	count, err := r.collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// records which label the spam classifier was trained with for this comment
+func (r *CommentRepository) SetTrainedAs(id primitive.ObjectID, label string) error {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"trained_as": label}})
+	return err
+}
+
+func (r *CommentRepository) UpdateContent(id primitive.ObjectID, content, contentHTML string, mentions []domain.Mention, notifiedUsers []primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "deleted": bson.M{"$ne": true}},
+		bson.M{"$set": bson.M{
+			"content":        content,
+			"content_html":   contentHTML,
+			"mentions":       mentions,
+			"notified_users": notifiedUsers,
+			"updated_at":     time.Now(),
+		}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update comment: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("comment not found for update")
+	}
+	return nil
+}
+
+func (r *CommentRepository) Delete(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errors.New("comment not found for delete")
+	}
+	return nil
+}
+
+// blanks out a comment that still has replies so the thread stays intact
+func (r *CommentRepository) MarkDeleted(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{
+			"content":      "",
+			"content_html": "",
+			"deleted":      true,
+			"updated_at":   time.Now(),
+		}, "$unset": bson.M{"mentions": ""}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("comment not found for delete")
+	}
+	return nil
+}
+
+func (r *CommentRepository) IncrementReplyCount(id primitive.ObjectID, delta int) error {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$inc": bson.M{"reply_count": delta}})
+	return err
+}
+
+// removes every comment of a blog, used when the blog is purged
+func (r *CommentRepository) DeleteByBlog(blogID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 30*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(ctx, bson.M{"blog_id": blogID})
+	return err
+}
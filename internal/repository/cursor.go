@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"errors"
+
+	"Blog-API/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// matches the items after cursor in the order given by sort, or the ones before it when
+// the cursor reads backward. sort must end with _id so that every item has its own
+// position.
+func keysetFilter(sort bson.D, cursor *domain.Cursor) (bson.M, error) {
+	values := append(append([]interface{}{}, cursor.Key...), cursor.ID)
+	if len(values) != len(sort) {
+		return nil, errors.New("invalid cursor")
+	}
+
+	// (a, b, _id) > (x, y, id) expands to a > x, or a = x and b > y, or a = x and b = y and _id > id
+	or := bson.A{}
+	for i, field := range sort {
+		op := "$gt"
+		if (field.Value.(int) < 0) != cursor.Backward {
+			op = "$lt"
+		}
+		condition := bson.M{field.Key: bson.M{op: values[i]}}
+		for j := 0; j < i; j++ {
+			condition[sort[j].Key] = values[j]
+		}
+		or = append(or, condition)
+	}
+	return bson.M{"$or": or}, nil
+}
+
+// the same order with every direction flipped, to read a page backward
+func reverseSort(sort bson.D) bson.D {
+	reversed := make(bson.D, 0, len(sort))
+	for _, field := range sort {
+		reversed = append(reversed, bson.E{Key: field.Key, Value: -field.Value.(int)})
+	}
+	return reversed
+}
+
+// the cursors of the pages around one read after cursor; first and last are cursors at
+// its first and last items, nil when it is empty, and more tells whether the read found
+// items beyond it
+func cursorPage(after *domain.Cursor, more bool, first, last *domain.Cursor) *domain.CursorPage {
+	page := &domain.CursorPage{}
+	if first == nil || last == nil {
+		return page
+	}
+	backward := after != nil && after.Backward
+	// reading forward there is a page before whenever the read started from a cursor,
+	// reading backward there always is a page after
+	if more || backward {
+		page.Next = last.Encode()
+	}
+	if (after != nil && !backward) || (backward && more) {
+		first.Backward = true
+		page.Prev = first.Encode()
+	}
+	return page
+}
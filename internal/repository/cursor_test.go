@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"Blog-API/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestKeysetFilter(t *testing.T) {
+	id := primitive.NewObjectID()
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	newest := bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}
+	top := bson.D{{Key: "reply_count", Value: -1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}
+
+	tests := []struct {
+		name   string
+		sort   bson.D
+		cursor domain.Cursor
+		want   bson.M
+	}{
+		{
+			name:   "descending forward reads older items",
+			sort:   newest,
+			cursor: domain.Cursor{Key: []interface{}{at}, ID: id},
+			want: bson.M{"$or": bson.A{
+				bson.M{"created_at": bson.M{"$lt": at}},
+				bson.M{"created_at": at, "_id": bson.M{"$lt": id}},
+			}},
+		},
+		{
+			name:   "descending backward reads newer items",
+			sort:   newest,
+			cursor: domain.Cursor{Key: []interface{}{at}, ID: id, Backward: true},
+			want: bson.M{"$or": bson.A{
+				bson.M{"created_at": bson.M{"$gt": at}},
+				bson.M{"created_at": at, "_id": bson.M{"$gt": id}},
+			}},
+		},
+		{
+			name:   "mixed directions compare each field its own way",
+			sort:   top,
+			cursor: domain.Cursor{Key: []interface{}{3, at}, ID: id},
+			want: bson.M{"$or": bson.A{
+				bson.M{"reply_count": bson.M{"$lt": 3}},
+				bson.M{"reply_count": 3, "created_at": bson.M{"$gt": at}},
+				bson.M{"reply_count": 3, "created_at": at, "_id": bson.M{"$gt": id}},
+			}},
+		},
+		{
+			name:   "ascending on _id alone",
+			sort:   bson.D{{Key: "_id", Value: 1}},
+			cursor: domain.Cursor{ID: id},
+			want:   bson.M{"$or": bson.A{bson.M{"_id": bson.M{"$gt": id}}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := keysetFilter(tt.sort, &tt.cursor)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeysetFilterRejectsMismatchedCursor(t *testing.T) {
+	sort := bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}
+	for _, key := range [][]interface{}{nil, {1, 2}} {
+		if _, err := keysetFilter(sort, &domain.Cursor{Key: key, ID: primitive.NewObjectID()}); err == nil {
+			t.Fatalf("expected a cursor with %d key values to be rejected", len(key))
+		}
+	}
+}
+
+func TestReverseSort(t *testing.T) {
+	sort := bson.D{{Key: "reply_count", Value: -1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}
+	want := bson.D{{Key: "reply_count", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}
+	if got := reverseSort(sort); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if sort[0].Value != -1 {
+		t.Fatal("reverseSort changed its argument")
+	}
+}
+
+func TestCursorPage(t *testing.T) {
+	forward := &domain.Cursor{Sort: "newest", ID: primitive.NewObjectID()}
+	backward := &domain.Cursor{Sort: "newest", ID: primitive.NewObjectID(), Backward: true}
+
+	tests := []struct {
+		name     string
+		after    *domain.Cursor
+		more     bool
+		empty    bool
+		wantNext bool
+		wantPrev bool
+	}{
+		{name: "only page", after: nil, more: false},
+		{name: "first of several", after: nil, more: true, wantNext: true},
+		{name: "middle read forward", after: forward, more: true, wantNext: true, wantPrev: true},
+		{name: "last read forward", after: forward, more: false, wantPrev: true},
+		{name: "middle read backward", after: backward, more: true, wantNext: true, wantPrev: true},
+		{name: "first read backward", after: backward, more: false, wantNext: true},
+		{name: "empty page", after: forward, empty: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var first, last *domain.Cursor
+			if !tt.empty {
+				first = &domain.Cursor{Sort: "newest", ID: primitive.NewObjectID()}
+				last = &domain.Cursor{Sort: "newest", ID: primitive.NewObjectID()}
+			}
+			page := cursorPage(tt.after, tt.more, first, last)
+			if (page.Next != "") != tt.wantNext || (page.Prev != "") != tt.wantPrev {
+				t.Fatalf("got next=%q prev=%q, want next=%v prev=%v", page.Next, page.Prev, tt.wantNext, tt.wantPrev)
+			}
+
+			if page.Next != "" {
+				next, err := domain.ParseCursor(page.Next, "newest")
+				if err != nil || next.ID != last.ID || next.Backward {
+					t.Fatalf("next cursor %+v (%v) should read forward from the last item", next, err)
+				}
+			}
+			if page.Prev != "" {
+				prev, err := domain.ParseCursor(page.Prev, "newest")
+				if err != nil || prev.ID != first.ID || !prev.Backward {
+					t.Fatalf("prev cursor %+v (%v) should read backward from the first item", prev, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MigrateEmbeddedComments moves comments embedded in blog documents into the
+// comments collection. It is safe to run more than once: comments are upserted
+// by their original ID and the embedded array is only removed afterwards.
+// Returns the number of comments moved.
+func MigrateEmbeddedComments(db *database.MongoDB) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	blogs := db.GetCollection("blogs")
+	comments := db.GetCollection("comments")
+
+	filter := bson.M{"comments.0": bson.M{"$exists": true}}
+	opts := options.Find().SetProjection(bson.M{"_id": 1, "comments": 1})
+
+	curr, err := blogs.Find(ctx, filter, opts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find blogs with embedded comments: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	moved := 0
+	for curr.Next(ctx) {
+		var doc struct {
+			ID       primitive.ObjectID `bson:"_id"`
+			Comments []struct {
+				ID             primitive.ObjectID `bson:"_id"`
+				AuthorID       primitive.ObjectID `bson:"author_id"`
+				AuthorUsername string             `bson:"author_username"`
+				Content        string             `bson:"content"`
+				CreatedAt      time.Time          `bson:"created_at"`
+				UpdatedAt      time.Time          `bson:"updated_at"`
+			} `bson:"comments"`
+		}
+		if err := curr.Decode(&doc); err != nil {
+			return moved, fmt.Errorf("failed to decode blog: %w", err)
+		}
+
+		writes := make([]mongo.WriteModel, 0, len(doc.Comments))
+		for _, c := range doc.Comments {
+			if c.ID.IsZero() {
+				c.ID = primitive.NewObjectID()
+			}
+			comment := domain.Comment{
+				ID:             c.ID,
+				BlogID:         doc.ID,
+				AuthorID:       c.AuthorID,
+				AuthorUsername: c.AuthorUsername,
+				Content:        c.Content,
+				Status:         domain.CommentApproved, // embedded comments were never moderated
+				CreatedAt:      c.CreatedAt,
+				UpdatedAt:      c.UpdatedAt,
+			}
+			writes = append(writes, mongo.NewReplaceOneModel().
+				SetFilter(bson.M{"_id": comment.ID}).
+				SetReplacement(comment).
+				SetUpsert(true))
+		}
+		if _, err := comments.BulkWrite(ctx, writes); err != nil {
+			return moved, fmt.Errorf("failed to copy comments of blog %s: %w", doc.ID.Hex(), err)
+		}
+
+		count, err := comments.CountDocuments(ctx, bson.M{"blog_id": doc.ID})
+		if err != nil {
+			return moved, err
+		}
+		_, err = blogs.UpdateOne(ctx,
+			bson.M{"_id": doc.ID},
+			bson.M{
+				"$set":   bson.M{"comment_count": count},
+				"$unset": bson.M{"comments": ""},
+			},
+		)
+		if err != nil {
+			return moved, fmt.Errorf("failed to update blog %s: %w", doc.ID.Hex(), err)
+		}
+		moved += len(doc.Comments)
+	}
+
+	return moved, curr.Err()
+}
+
+// MigrateEmbeddedReactions moves the likes and dislikes arrays of blog documents into
+// the reactions collection and recomputes reaction_counts and like_count from it. It is
+// safe to run more than once and next to the server: reactions are inserted only when
+// the user has none on the blog yet, and the arrays are only removed afterwards. A user
+// found in both arrays keeps the like. Returns the number of reactions moved.
+func MigrateEmbeddedReactions(db *database.MongoDB) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	blogs := db.GetCollection("blogs")
+	reactions := db.GetCollection("reactions")
+	users := db.GetCollection("users")
+
+	filter := bson.M{"$or": bson.A{
+		bson.M{"likes.0": bson.M{"$exists": true}},
+		bson.M{"dislikes.0": bson.M{"$exists": true}},
+	}}
+	opts := options.Find().SetProjection(bson.M{"_id": 1, "likes": 1, "dislikes": 1, "updated_at": 1})
+
+	curr, err := blogs.Find(ctx, filter, opts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find blogs with embedded reactions: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	moved := 0
+	for curr.Next(ctx) {
+		var doc struct {
+			ID        primitive.ObjectID `bson:"_id"`
+			Likes     []string           `bson:"likes"`
+			Dislikes  []string           `bson:"dislikes"`
+			UpdatedAt time.Time          `bson:"updated_at"`
+		}
+		if err := curr.Decode(&doc); err != nil {
+			return moved, fmt.Errorf("failed to decode blog: %w", err)
+		}
+
+		// the arrays hold user IDs as hex strings; unparsable ones are dropped
+		reactionTypes := map[primitive.ObjectID]string{}
+		userIDs := bson.A{}
+		for _, embedded := range []struct {
+			reactionType string
+			userIDs      []string
+		}{{domain.ReactionDislike, doc.Dislikes}, {domain.ReactionLike, doc.Likes}} {
+			for _, hex := range embedded.userIDs {
+				userID, err := primitive.ObjectIDFromHex(hex)
+				if err != nil {
+					continue
+				}
+				if _, ok := reactionTypes[userID]; !ok {
+					userIDs = append(userIDs, userID)
+				}
+				reactionTypes[userID] = embedded.reactionType
+			}
+		}
+
+		usernames, err := migrationUsernames(ctx, users, userIDs)
+		if err != nil {
+			return moved, err
+		}
+
+		writes := make([]mongo.WriteModel, 0, len(reactionTypes))
+		for userID, reactionType := range reactionTypes {
+			writes = append(writes, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"blog_id": doc.ID, "user_id": userID}).
+				SetUpdate(bson.M{"$setOnInsert": bson.M{
+					"username":      usernames[userID],
+					"reaction_type": reactionType,
+					"created_at":    doc.UpdatedAt,
+					"updated_at":    doc.UpdatedAt,
+				}}).
+				SetUpsert(true))
+		}
+		if len(writes) > 0 {
+			if _, err := reactions.BulkWrite(ctx, writes); err != nil {
+				return moved, fmt.Errorf("failed to copy reactions of blog %s: %w", doc.ID.Hex(), err)
+			}
+		}
+
+		counts, err := migrationReactionCounts(ctx, reactions, doc.ID)
+		if err != nil {
+			return moved, err
+		}
+		_, err = blogs.UpdateOne(ctx,
+			bson.M{"_id": doc.ID},
+			bson.M{
+				"$set":   bson.M{"reaction_counts": counts, "like_count": counts[domain.ReactionLike]},
+				"$unset": bson.M{"likes": "", "dislikes": ""},
+			},
+		)
+		if err != nil {
+			return moved, fmt.Errorf("failed to update blog %s: %w", doc.ID.Hex(), err)
+		}
+		moved += len(writes)
+	}
+
+	return moved, curr.Err()
+}
+
+// looks up the usernames reactions are listed with
+func migrationUsernames(ctx context.Context, users *mongo.Collection, userIDs bson.A) (map[primitive.ObjectID]string, error) {
+	usernames := map[primitive.ObjectID]string{}
+	if len(userIDs) == 0 {
+		return usernames, nil
+	}
+	opts := options.Find().SetProjection(bson.M{"_id": 1, "username": 1})
+	curr, err := users.Find(ctx, bson.M{"_id": bson.M{"$in": userIDs}}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reacting users: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	for curr.Next(ctx) {
+		var user struct {
+			ID       primitive.ObjectID `bson:"_id"`
+			Username string             `bson:"username"`
+		}
+		if err := curr.Decode(&user); err != nil {
+			return nil, fmt.Errorf("failed to decode user: %w", err)
+		}
+		usernames[user.ID] = user.Username
+	}
+	return usernames, curr.Err()
+}
+
+// counts a blog's reactions per type, as reaction_counts stores them
+func migrationReactionCounts(ctx context.Context, reactions *mongo.Collection, blogID primitive.ObjectID) (map[string]int, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"blog_id": blogID}}},
+		{{Key: "$group", Value: bson.M{"_id": "$reaction_type", "count": bson.M{"$sum": 1}}}},
+	}
+	curr, err := reactions.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reactions of blog %s: %w", blogID.Hex(), err)
+	}
+	defer curr.Close(ctx)
+
+	counts := map[string]int{}
+	for curr.Next(ctx) {
+		var group struct {
+			ReactionType string `bson:"_id"`
+			Count        int    `bson:"count"`
+		}
+		if err := curr.Decode(&group); err != nil {
+			return nil, fmt.Errorf("failed to decode reaction count: %w", err)
+		}
+		counts[group.ReactionType] = group.Count
+	}
+	return counts, curr.Err()
+}
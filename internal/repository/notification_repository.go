@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type NotificationRepository struct {
+	db         *database.MongoDB
+	collection *mongo.Collection
+}
+
+func NewNotificationRepository(db *database.MongoDB) domain.NotificationRepository {
+	collection := db.GetCollection("notifications")
+
+	indexModels := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "recipient_id", Value: 1}, {Key: "updated_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "recipient_id", Value: 1}, {Key: "read", Value: 1}},
+		},
+		{
+			// at most one unread notification per group
+			Keys: bson.D{{Key: "recipient_id", Value: 1}, {Key: "group_key", Value: 1}},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{
+				"read":      false,
+				"group_key": bson.M{"$exists": true},
+			}),
+		},
+	}
+	// indexes might already exist, so an error here is not fatal
+	_, _ = collection.Indexes().CreateMany(context.Background(), indexModels)
+
+	return &NotificationRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+func (r *NotificationRepository) Create(notification *domain.Notification) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.InsertOne(ctx, notification)
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		notification.ID = oid
+	}
+	return nil
+}
+
+// folds the notification into the recipient's unread notification with the same group key,
+// creating it when there is none. Each actor is only counted once per group.
+func (r *NotificationRepository) Group(notification *domain.Notification) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"recipient_id": notification.RecipientID,
+		"group_key":    notification.GroupKey,
+		"read":         false,
+	}
+	update := bson.M{
+		"$addToSet": bson.M{"actor_ids": notification.ActorID},
+		"$set": bson.M{
+			"actor_id":       notification.ActorID,
+			"actor_username": notification.ActorUsername,
+			"updated_at":     notification.UpdatedAt,
+		},
+		"$setOnInsert": bson.M{
+			"type":       notification.Type,
+			"blog_id":    notification.BlogID,
+			"comment_id": notification.CommentID,
+			"created_at": notification.CreatedAt,
+		},
+	}
+	opts := options.Update().SetUpsert(true)
+
+	_, err := r.collection.UpdateOne(ctx, filter, update, opts)
+	if mongo.IsDuplicateKeyError(err) {
+		// a concurrent upsert created the group first, so join it
+		_, err = r.collection.UpdateOne(ctx, filter, update)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to group notification: %w", err)
+	}
+	return nil
+}
+
+// lists a user's notifications, most recently active first
+func (r *NotificationRepository) List(recipientID primitive.ObjectID, unreadOnly bool, page, limit int) ([]*domain.Notification, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	notifications := []*domain.Notification{}
+	filter := bson.M{"recipient_id": recipientID}
+	if unreadOnly {
+		filter["read"] = false
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "updated_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(page-1) * int64(limit))
+
+	curr, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find notifications: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	if err := curr.All(ctx, &notifications); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return notifications, total, nil
+}
+
+func (r *NotificationRepository) CountUnread(recipientID primitive.ObjectID) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return r.collection.CountDocuments(ctx, bson.M{"recipient_id": recipientID, "read": false})
+}
+
+func (r *NotificationRepository) MarkRead(id, recipientID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "recipient_id": recipientID},
+		bson.M{"$set": bson.M{"read": true, "read_at": time.Now()}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification read: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("notification not found")
+	}
+	return nil
+}
+
+func (r *NotificationRepository) MarkAllRead(recipientID primitive.ObjectID) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateMany(ctx,
+		bson.M{"recipient_id": recipientID, "read": false},
+		bson.M{"$set": bson.M{"read": true, "read_at": time.Now()}},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+	return result.ModifiedCount, nil
+}
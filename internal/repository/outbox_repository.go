@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// how long dispatched messages are kept before mongodb removes them
+const outboxRetention = 7 * 24 * time.Hour
+
+type OutboxRepository struct {
+	db         *database.MongoDB
+	collection *mongo.Collection
+	ctx        context.Context // set by WithContext, e.g. to join a transaction
+}
+
+func NewOutboxRepository(db *database.MongoDB) domain.OutboxRepository {
+	collection := db.GetCollection("outbox")
+
+	indexModels := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}},
+		},
+		{
+			// pending messages have no dispatched_at and are never expired
+			Keys:    bson.D{{Key: "dispatched_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(outboxRetention.Seconds())),
+		},
+	}
+	// indexes might already exist, so an error here is not fatal
+	_, _ = collection.Indexes().CreateMany(context.Background(), indexModels)
+
+	return &OutboxRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+// returns a copy of the repository whose operations run within ctx
+func (r *OutboxRepository) WithContext(ctx context.Context) domain.OutboxRepository {
+	clone := *r
+	clone.ctx = ctx
+	return &clone
+}
+
+// records events to be dispatched, in the caller's transaction when bound to one
+func (r *OutboxRepository) Append(events ...domain.DomainEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	documents := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s event: %w", event.EventName(), err)
+		}
+		documents = append(documents, &domain.OutboxMessage{
+			ID:            primitive.NewObjectID(),
+			Name:          event.EventName(),
+			Payload:       string(payload),
+			Status:        domain.OutboxPending,
+			NextAttemptAt: now,
+			OccurredAt:    now,
+		})
+	}
+
+	if _, err := r.collection.InsertMany(ctx, documents); err != nil {
+		return fmt.Errorf("failed to append to outbox: %w", err)
+	}
+	return nil
+}
+
+// pushes the next attempt of the oldest due message past the lease and returns it,
+// or nil when nothing is due. A dispatcher that dies mid-way leaves the message to be retried.
+func (r *OutboxRepository) ClaimPending(now time.Time, lease time.Duration) (*domain.OutboxMessage, error) {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	var message domain.OutboxMessage
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{"status": domain.OutboxPending, "next_attempt_at": bson.M{"$lte": now}},
+		bson.M{"$set": bson.M{"next_attempt_at": now.Add(lease)}},
+		options.FindOneAndUpdate().
+			SetSort(bson.D{{Key: "next_attempt_at", Value: 1}, {Key: "_id", Value: 1}}).
+			SetReturnDocument(options.After),
+	).Decode(&message)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim outbox message: %w", err)
+	}
+	return &message, nil
+}
+
+func (r *OutboxRepository) Update(message *domain.OutboxMessage) error {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": message.ID}, bson.M{"$set": message})
+	if err != nil {
+		return fmt.Errorf("failed to update outbox message: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("outbox message not found for update")
+	}
+	return nil
+}
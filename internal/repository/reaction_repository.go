@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ReactionRepository struct {
+	db         *database.MongoDB
+	collection *mongo.Collection
+	ctx        context.Context // set by WithContext, e.g. to join a transaction
+}
+
+func NewReactionRepository(db *database.MongoDB) domain.ReactionRepository {
+	collection := db.GetCollection("reactions")
+
+	indexModels := []mongo.IndexModel{
+		{
+			// one reaction per user and blog
+			Keys:    bson.D{{Key: "blog_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "blog_id", Value: 1}, {Key: "reaction_type", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}},
+		},
+	}
+	// indexes might already exist, so an error here is not fatal
+	_, _ = collection.Indexes().CreateMany(context.Background(), indexModels)
+
+	return &ReactionRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+// returns a copy of the repository whose operations run within ctx
+func (r *ReactionRepository) WithContext(ctx context.Context) domain.ReactionRepository {
+	clone := *r
+	clone.ctx = ctx
+	return &clone
+}
+
+func (r *ReactionRepository) GetByBlogAndUser(blogID, userID primitive.ObjectID) (*domain.Reaction, error) {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	var reaction domain.Reaction
+	err := r.collection.FindOne(ctx, bson.M{"blog_id": blogID, "user_id": userID}).Decode(&reaction)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("reaction not found")
+		}
+		return nil, fmt.Errorf("database error in GetByBlogAndUser: %w", err)
+	}
+	return &reaction, nil
+}
+
+func (r *ReactionRepository) Upsert(reaction *domain.Reaction) (*domain.Reaction, error) {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"blog_id": reaction.BlogID, "user_id": reaction.UserID}
+	update := bson.M{
+		"$set": bson.M{
+			"username":      reaction.Username,
+			"reaction_type": reaction.ReactionType,
+			"updated_at":    reaction.UpdatedAt,
+		},
+		"$setOnInsert": bson.M{"created_at": reaction.CreatedAt},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before)
+
+	var previous domain.Reaction
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&previous)
+	if mongo.IsDuplicateKeyError(err) {
+		// a concurrent upsert inserted the reaction first, so update it
+		err = r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&previous)
+	}
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to save reaction: %w", err)
+	}
+	return &previous, nil
+}
+
+func (r *ReactionRepository) Delete(blogID, userID primitive.ObjectID, reactionType string) (*domain.Reaction, error) {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"blog_id": blogID, "user_id": userID}
+	if reactionType != "" {
+		filter["reaction_type"] = reactionType
+	}
+	var removed domain.Reaction
+	err := r.collection.FindOneAndDelete(ctx, filter).Decode(&removed)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to delete reaction: %w", err)
+	}
+	return &removed, nil
+}
+
+// lists who reacted to a blog, most recent first, optionally only with one reaction type
+func (r *ReactionRepository) ListByBlog(blogID primitive.ObjectID, reactionType string, page, limit int) ([]*domain.Reaction, int64, error) {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	reactions := []*domain.Reaction{}
+	filter := bson.M{"blog_id": blogID}
+	if reactionType != "" {
+		filter["reaction_type"] = reactionType
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(page-1) * int64(limit))
+
+	curr, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find reactions: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	if err := curr.All(ctx, &reactions); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return reactions, total, nil
+}
+
+// removes every reaction to a blog, used when the blog is purged
+func (r *ReactionRepository) DeleteByBlog(blogID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 30*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(ctx, bson.M{"blog_id": blogID})
+	return err
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ReadingListRepository struct {
+	db         *database.MongoDB
+	collection *mongo.Collection
+}
+
+func NewReadingListRepository(db *database.MongoDB) domain.ReadingListRepository {
+	collection := db.GetCollection("reading_lists")
+
+	indexModels := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "owner_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+	}
+	// indexes might already exist, so an error here is not fatal
+	_, _ = collection.Indexes().CreateMany(context.Background(), indexModels)
+
+	return &ReadingListRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+func (r *ReadingListRepository) Create(list *domain.ReadingList) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.InsertOne(ctx, list)
+	if err != nil {
+		return fmt.Errorf("failed to create reading list: %w", err)
+	}
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		list.ID = oid
+	}
+	return nil
+}
+
+func (r *ReadingListRepository) GetByID(id primitive.ObjectID) (*domain.ReadingList, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var list domain.ReadingList
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&list)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("reading list not found")
+		}
+		return nil, fmt.Errorf("database error in GetByID: %w", err)
+	}
+	return &list, nil
+}
+
+func (r *ReadingListRepository) ListByOwner(ownerID primitive.ObjectID, publicOnly bool) ([]*domain.ReadingList, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	lists := []*domain.ReadingList{}
+	filter := bson.M{"owner_id": ownerID}
+	if publicOnly {
+		filter["public"] = true
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	curr, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reading lists: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	if err := curr.All(ctx, &lists); err != nil {
+		return nil, err
+	}
+	return lists, nil
+}
+
+func (r *ReadingListRepository) Update(list *domain.ReadingList) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": list.ID}, bson.M{"$set": list})
+	if err != nil {
+		return fmt.Errorf("failed to update reading list: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("reading list not found for update")
+	}
+	return nil
+}
+
+func (r *ReadingListRepository) Delete(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errors.New("reading list not found for delete")
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SeriesRepository struct {
+	db         *database.MongoDB
+	collection *mongo.Collection
+}
+
+func NewSeriesRepository(db *database.MongoDB) domain.SeriesRepository {
+	collection := db.GetCollection("series")
+
+	indexModels := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "owner_id", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "blog_ids", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "created_at", Value: -1}},
+		},
+	}
+	// indexes might already exist, so an error here is not fatal
+	_, _ = collection.Indexes().CreateMany(context.Background(), indexModels)
+
+	return &SeriesRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+func (r *SeriesRepository) Create(series *domain.Series) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.InsertOne(ctx, series)
+	if err != nil {
+		return fmt.Errorf("failed to create series: %w", err)
+	}
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		series.ID = oid
+	}
+	return nil
+}
+
+func (r *SeriesRepository) GetByID(id primitive.ObjectID) (*domain.Series, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var series domain.Series
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&series)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("series not found")
+		}
+		return nil, fmt.Errorf("database error in GetByID: %w", err)
+	}
+	return &series, nil
+}
+
+// finds the series a blog belongs to
+func (r *SeriesRepository) GetByBlogID(blogID primitive.ObjectID) (*domain.Series, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var series domain.Series
+	err := r.collection.FindOne(ctx, bson.M{"blog_ids": blogID}).Decode(&series)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("series not found")
+		}
+		return nil, fmt.Errorf("database error in GetByBlogID: %w", err)
+	}
+	return &series, nil
+}
+
+func (r *SeriesRepository) List(page, limit int) ([]*domain.Series, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var series []*domain.Series
+	filter := bson.M{}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(page-1) * int64(limit))
+
+	curr, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find series: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	if err := curr.All(ctx, &series); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return series, total, nil
+}
+
+func (r *SeriesRepository) Update(series *domain.Series) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": series.ID}, bson.M{"$set": series})
+	if err != nil {
+		return fmt.Errorf("failed to update series: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("series not found for update")
+	}
+	return nil
+}
+
+func (r *SeriesRepository) Delete(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errors.New("series not found for delete")
+	}
+	return nil
+}
+
+// pulls a blog out of any series that contains it
+func (r *SeriesRepository) RemoveBlog(blogID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"blog_ids": blogID},
+		bson.M{
+			"$pull": bson.M{"blog_ids": blogID},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+	)
+	return err
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SettingsRepository struct {
+	db         *database.MongoDB
+	collection *mongo.Collection
+}
+
+func NewSettingsRepository(db *database.MongoDB) domain.SettingsRepository {
+	return &SettingsRepository{
+		db:         db,
+		collection: db.GetCollection("settings"),
+	}
+}
+
+func (r *SettingsRepository) Get(key string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var setting domain.Setting
+	err := r.collection.FindOne(ctx, bson.M{"_id": key}).Decode(&setting)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return "", errors.New("setting not found")
+		}
+		return "", fmt.Errorf("database error in Get: %w", err)
+	}
+	return setting.Value, nil
+}
+
+func (r *SettingsRepository) Set(key, value string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": key},
+		bson.M{"$set": bson.M{
+			"value":      value,
+			"updated_at": time.Now(),
+		}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// id of the document holding the number of trained spam and ham samples
+const spamTotalsID = "__totals__"
+
+type SpamModelRepository struct {
+	db         *database.MongoDB
+	collection *mongo.Collection
+}
+
+func NewSpamModelRepository(db *database.MongoDB) domain.SpamModelRepository {
+	return &SpamModelRepository{
+		db:         db,
+		collection: db.GetCollection("spam_tokens"),
+	}
+}
+
+func (r *SpamModelRepository) GetDocumentCounts() (int, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var totals domain.SpamTokenStats
+	err := r.collection.FindOne(ctx, bson.M{"_id": spamTotalsID}).Decode(&totals)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return 0, 0, nil
+		}
+		return 0, 0, fmt.Errorf("database error in GetDocumentCounts: %w", err)
+	}
+	return totals.Spam, totals.Ham, nil
+}
+
+func (r *SpamModelRepository) GetTokenStats(tokens []string) (map[string]domain.SpamTokenStats, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stats := make(map[string]domain.SpamTokenStats, len(tokens))
+	if len(tokens) == 0 {
+		return stats, nil
+	}
+
+	curr, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": tokens}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find spam tokens: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	for curr.Next(ctx) {
+		var s domain.SpamTokenStats
+		if err := curr.Decode(&s); err != nil {
+			return nil, err
+		}
+		stats[s.Token] = s
+	}
+	return stats, curr.Err()
+}
+
+// adds (delta 1) or removes (delta -1) one training sample in a single bulk write
+func (r *SpamModelRepository) AddSamples(tokens []string, label string, delta int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	field := "ham"
+	if label == domain.SpamLabelSpam {
+		field = "spam"
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(tokens)+1)
+	writes = append(writes, mongo.NewUpdateOneModel().
+		SetFilter(bson.M{"_id": spamTotalsID}).
+		SetUpdate(bson.M{"$inc": bson.M{field: delta}}).
+		SetUpsert(true))
+	for _, token := range tokens {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": token}).
+			SetUpdate(bson.M{"$inc": bson.M{field: delta}}).
+			SetUpsert(true))
+	}
+
+	_, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return fmt.Errorf("failed to update spam model: %w", err)
+	}
+	return nil
+}
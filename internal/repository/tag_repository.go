@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TagRepository struct {
+	db         *database.MongoDB
+	collection *mongo.Collection
+	ctx        context.Context // set by WithContext, e.g. to join a transaction
+}
+
+func NewTagRepository(db *database.MongoDB) domain.TagRepository {
+	collection := db.GetCollection("tags")
+
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "name", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "usage_count", Value: -1}, {Key: "name", Value: 1}},
+		},
+	}
+	// indexes might already exist, so an error here is not fatal
+	_, _ = collection.Indexes().CreateMany(context.Background(), indexModels)
+
+	return &TagRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+// returns a copy of the repository whose operations run within ctx
+func (r *TagRepository) WithContext(ctx context.Context) domain.TagRepository {
+	clone := *r
+	clone.ctx = ctx
+	return &clone
+}
+
+func (r *TagRepository) Create(tag *domain.Tag) error {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.InsertOne(ctx, tag)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return errors.New("tag already exists")
+		}
+		return fmt.Errorf("failed to create tag: %w", err)
+	}
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		tag.ID = oid
+	}
+	return nil
+}
+
+func (r *TagRepository) GetByName(name string) (*domain.Tag, error) {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	var tag domain.Tag
+	err := r.collection.FindOne(ctx, bson.M{"name": name}).Decode(&tag)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("tag not found")
+		}
+		return nil, fmt.Errorf("database error in GetByName: %w", err)
+	}
+	return &tag, nil
+}
+
+func (r *TagRepository) GetByNames(names []string) ([]*domain.Tag, error) {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	tags := []*domain.Tag{}
+	curr, err := r.collection.Find(ctx, bson.M{"name": bson.M{"$in": names}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tags: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	if err := curr.All(ctx, &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func (r *TagRepository) SearchPrefix(prefix string, limit int) ([]*domain.Tag, error) {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	tags := []*domain.Tag{}
+	// an anchored regex can use the name index
+	filter := bson.M{"name": bson.M{"$regex": "^" + regexp.QuoteMeta(prefix)}}
+	opts := options.Find()
+	opts.SetSort(bson.D{{Key: "usage_count", Value: -1}, {Key: "name", Value: 1}})
+	opts.SetLimit(int64(limit))
+
+	curr, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tags: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	if err := curr.All(ctx, &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func (r *TagRepository) ListPopular(page, limit int) ([]*domain.Tag, int64, error) {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	tags := []*domain.Tag{}
+	opts := options.Find()
+	opts.SetSort(bson.D{{Key: "usage_count", Value: -1}, {Key: "name", Value: 1}})
+	opts.SetLimit(int64(limit))
+	opts.SetSkip(int64(page-1) * int64(limit))
+
+	curr, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find tags: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	if err := curr.All(ctx, &tags); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.collection.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return tags, total, nil
+}
+
+func (r *TagRepository) Update(tag *domain.Tag) error {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": tag.ID},
+		bson.M{"$set": bson.M{
+			"name":        tag.Name,
+			"description": tag.Description,
+			"updated_at":  tag.UpdatedAt,
+		}},
+	)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return errors.New("tag already exists")
+		}
+		return fmt.Errorf("failed to update tag: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("tag not found for update")
+	}
+	return nil
+}
+
+func (r *TagRepository) Delete(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errors.New("tag not found for delete")
+	}
+	return nil
+}
+
+// tags are registered by the first blog that uses them; decrements never create a tag
+func (r *TagRepository) IncrementUsage(deltas map[string]int) error {
+	now := time.Now()
+	models := []mongo.WriteModel{}
+	for name, delta := range deltas {
+		if delta == 0 {
+			continue
+		}
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"name": name}).
+			SetUpdate(bson.M{
+				"$inc":         bson.M{"usage_count": delta},
+				"$setOnInsert": bson.M{"created_at": now, "updated_at": now},
+			}).
+			SetUpsert(delta > 0))
+	}
+	if len(models) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	if _, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to update tag usage: %w", err)
+	}
+	return nil
+}
+
+func (r *TagRepository) SetUsage(name string, count int64) error {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"name": name}, bson.M{"$set": bson.M{"usage_count": count}})
+	return err
+}
+
+func (r *TagRepository) ResetUsage(counts map[string]int64) error {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 30*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	names := make([]string, 0, len(counts))
+	models := make([]mongo.WriteModel, 0, len(counts))
+	for name, count := range counts {
+		names = append(names, name)
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"name": name}).
+			SetUpdate(bson.M{
+				"$set":         bson.M{"usage_count": count},
+				"$setOnInsert": bson.M{"created_at": now, "updated_at": now},
+			}).
+			SetUpsert(true))
+	}
+	if len(models) > 0 {
+		if _, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+			return fmt.Errorf("failed to reset tag usage: %w", err)
+		}
+	}
+
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"name": bson.M{"$nin": names}, "usage_count": bson.M{"$ne": 0}},
+		bson.M{"$set": bson.M{"usage_count": 0}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to reset tag usage: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TagSynonymRepository struct {
+	db         *database.MongoDB
+	collection *mongo.Collection
+	ctx        context.Context // set by WithContext, e.g. to join a transaction
+}
+
+func NewTagSynonymRepository(db *database.MongoDB) domain.TagSynonymRepository {
+	collection := db.GetCollection("tag_synonyms")
+
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "name", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "tag", Value: 1}},
+		},
+	}
+	// indexes might already exist, so an error here is not fatal
+	_, _ = collection.Indexes().CreateMany(context.Background(), indexModels)
+
+	return &TagSynonymRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+// returns a copy of the repository whose operations run within ctx
+func (r *TagSynonymRepository) WithContext(ctx context.Context) domain.TagSynonymRepository {
+	clone := *r
+	clone.ctx = ctx
+	return &clone
+}
+
+func (r *TagSynonymRepository) Create(synonym *domain.TagSynonym) error {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.InsertOne(ctx, synonym)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return errors.New("synonym already exists")
+		}
+		return fmt.Errorf("failed to create synonym: %w", err)
+	}
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		synonym.ID = oid
+	}
+	return nil
+}
+
+func (r *TagSynonymRepository) Delete(name string) error {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"name": name})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errors.New("synonym not found for delete")
+	}
+	return nil
+}
+
+func (r *TagSynonymRepository) ListByTags(tags []string) ([]*domain.TagSynonym, error) {
+	return r.find(bson.M{"tag": bson.M{"$in": tags}}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+}
+
+func (r *TagSynonymRepository) Resolve(names []string) (map[string]string, error) {
+	synonyms, err := r.find(bson.M{"name": bson.M{"$in": names}}, options.Find())
+	if err != nil {
+		return nil, err
+	}
+	resolved := make(map[string]string, len(synonyms))
+	for _, synonym := range synonyms {
+		resolved[synonym.Name] = synonym.Tag
+	}
+	return resolved, nil
+}
+
+func (r *TagSynonymRepository) SearchPrefix(prefix string, limit int) ([]*domain.TagSynonym, error) {
+	filter := bson.M{"name": bson.M{"$regex": "^" + regexp.QuoteMeta(prefix)}}
+	return r.find(filter, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}).SetLimit(int64(limit)))
+}
+
+func (r *TagSynonymRepository) Retarget(from, to string) error {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.UpdateMany(ctx, bson.M{"tag": from}, bson.M{"$set": bson.M{"tag": to}})
+	if err != nil {
+		return fmt.Errorf("failed to update synonyms: %w", err)
+	}
+	return nil
+}
+
+func (r *TagSynonymRepository) find(filter bson.M, opts *options.FindOptions) ([]*domain.TagSynonym, error) {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	synonyms := []*domain.TagSynonym{}
+	curr, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find synonyms: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	if err := curr.All(ctx, &synonyms); err != nil {
+		return nil, err
+	}
+	return synonyms, nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// base context for a repository operation, the one bound with WithContext if any
+func baseContext(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+	return ctx
+}
+
+type Transactor struct {
+	db        *database.MongoDB
+	once      sync.Once
+	supported bool
+}
+
+func NewTransactor(db *database.MongoDB) domain.Transactor {
+	return &Transactor{db: db}
+}
+
+// runs fn in a multi-document transaction. A standalone mongod does not support
+// transactions, there fn runs without one and its writes are not atomic.
+func (t *Transactor) WithTransaction(fn func(ctx context.Context) error) error {
+	if !t.transactionsSupported() {
+		return fn(context.Background())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	session, err := t.db.Client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	})
+	return err
+}
+
+// transactions need a replica set or a sharded cluster
+func (t *Transactor) transactionsSupported() bool {
+	t.once.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		var hello struct {
+			SetName string `bson:"setName"`
+			Msg     string `bson:"msg"`
+		}
+		err := t.db.Client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello)
+		t.supported = err == nil && (hello.SetName != "" || hello.Msg == "isdbgrid")
+		if !t.supported {
+			log.Printf("mongodb does not support transactions, outbox events are written without one")
+		}
+	})
+	return t.supported
+}
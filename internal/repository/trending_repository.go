@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// one document per window, keyed by the window name
+type TrendingRepository struct {
+	db         *database.MongoDB
+	collection *mongo.Collection
+}
+
+func NewTrendingRepository(db *database.MongoDB) domain.TrendingRepository {
+	return &TrendingRepository{
+		db:         db,
+		collection: db.GetCollection("trending"),
+	}
+}
+
+func (r *TrendingRepository) Save(ranking *domain.TrendingRanking) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": ranking.Window}, ranking, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save trending ranking: %w", err)
+	}
+	return nil
+}
+
+func (r *TrendingRepository) Get(window string) (*domain.TrendingRanking, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var ranking domain.TrendingRanking
+	err := r.collection.FindOne(ctx, bson.M{"_id": window}).Decode(&ranking)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("trending ranking not found")
+		}
+		return nil, fmt.Errorf("database error in Get: %w", err)
+	}
+	return &ranking, nil
+}
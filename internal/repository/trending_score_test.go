@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"Blog-API/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// evaluates the few aggregation operators trendingScore uses against a document, the
+// way mongodb would: dates subtract to milliseconds and missing fields are null
+func evalExpr(t *testing.T, expr interface{}, doc bson.M) interface{} {
+	t.Helper()
+	switch e := expr.(type) {
+	case string:
+		if !strings.HasPrefix(e, "$") {
+			return e
+		}
+		var value interface{} = doc
+		for _, key := range strings.Split(e[1:], ".") {
+			m, ok := value.(bson.M)
+			if !ok {
+				return nil
+			}
+			value = m[key]
+		}
+		return value
+	case bson.M:
+		if len(e) != 1 {
+			t.Fatalf("expression %v should have one operator", e)
+		}
+		for op, arg := range e {
+			args, _ := arg.(bson.A)
+			values := make([]interface{}, len(args))
+			for i, a := range args {
+				values[i] = evalExpr(t, a, doc)
+			}
+			switch op {
+			case "$add":
+				sum := 0.0
+				for _, v := range values {
+					sum += number(t, v)
+				}
+				return sum
+			case "$multiply":
+				product := 1.0
+				for _, v := range values {
+					product *= number(t, v)
+				}
+				return product
+			case "$ifNull":
+				if values[0] == nil {
+					return values[1]
+				}
+				return values[0]
+			case "$max":
+				return math.Max(number(t, values[0]), number(t, values[1]))
+			case "$subtract":
+				if a, ok := values[0].(time.Time); ok {
+					return float64(a.Sub(values[1].(time.Time)).Milliseconds())
+				}
+				return number(t, values[0]) - number(t, values[1])
+			case "$exp":
+				return math.Exp(number(t, evalExpr(t, arg, doc)))
+			}
+			t.Fatalf("unexpected operator %s", op)
+		}
+	}
+	return expr
+}
+
+func number(t *testing.T, v interface{}) float64 {
+	t.Helper()
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case float64:
+		return n
+	}
+	t.Fatalf("%v (%T) is not a number", v, v)
+	return 0
+}
+
+func TestTrendingScore(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	weights := domain.TrendingWeights{Views: 0.1, Likes: 1, Dislikes: 1, Comments: 2, HalfLife: 24 * time.Hour}
+	noDecay := weights
+	noDecay.HalfLife = 0
+
+	blog := func(age time.Duration, views, likes, dislikes, comments int) bson.M {
+		return bson.M{
+			"created_at":      now.Add(-age),
+			"view_count":      views,
+			"like_count":      likes,
+			"reaction_counts": bson.M{domain.ReactionDislike: dislikes},
+			"comment_count":   comments,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		weights domain.TrendingWeights
+		blog    bson.M
+		want    float64
+	}{
+		{"fresh blog keeps its full score", weights, blog(0, 100, 5, 1, 3), 10 + 5 - 1 + 6},
+		{"one half-life halves it", weights, blog(24*time.Hour, 100, 5, 1, 3), 20.0 / 2},
+		{"two half-lives quarter it", weights, blog(48*time.Hour, 100, 5, 1, 3), 20.0 / 4},
+		{"blogs dated in the future do not grow", weights, blog(-time.Hour, 100, 5, 1, 3), 20},
+		{"without a half-life nothing fades", noDecay, blog(30*24*time.Hour, 100, 5, 1, 3), 20},
+		{"dislikes can push it below zero", noDecay, blog(0, 0, 0, 4, 0), -4},
+		{"missing counters count as zero", noDecay, bson.M{"created_at": now, "like_count": 2}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := number(t, evalExpr(t, trendingScore(tt.weights, now), tt.blog))
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// the order the scores put blogs in: recent engagement beats older, larger engagement
+func TestTrendingScoreOrder(t *testing.T) {
+	now := time.Now()
+	weights := domain.TrendingWeights{Likes: 1, HalfLife: 24 * time.Hour}
+	scoreOf := func(age time.Duration, likes int) float64 {
+		doc := bson.M{"created_at": now.Add(-age), "like_count": likes}
+		return number(t, evalExpr(t, trendingScore(weights, now), doc))
+	}
+	recent, older := scoreOf(2*time.Hour, 10), scoreOf(72*time.Hour, 50)
+	if recent <= older {
+		t.Fatalf("recent %v should rank above older %v", recent, older)
+	}
+}
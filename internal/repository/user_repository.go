@@ -17,6 +17,7 @@ import (
 type UserRepository struct {
 	db         *database.MongoDB
 	collection *mongo.Collection
+	ctx        context.Context // set by WithContext, e.g. to join a transaction
 }
 
 func NewUserRepository(db *database.MongoDB) domain.UserRepository {
@@ -46,9 +47,16 @@ func NewUserRepository(db *database.MongoDB) domain.UserRepository {
 	}
 }
 
+// returns a copy of the repository whose operations run within ctx
+func (r *UserRepository) WithContext(ctx context.Context) domain.UserRepository {
+	clone := *r
+	clone.ctx = ctx
+	return &clone
+}
+
 // creates a new user
 func (r *UserRepository) Create(user *domain.User) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
 	defer cancel()
 
 	// Set timestamps
@@ -91,7 +99,7 @@ func (r *UserRepository) Create(user *domain.User) error {
 
 // retrieves a user by ID
 func (r *UserRepository) GetByID(id primitive.ObjectID) (*domain.User, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
 	defer cancel()
 
 	var user domain.User
@@ -108,7 +116,7 @@ func (r *UserRepository) GetByID(id primitive.ObjectID) (*domain.User, error) {
 
 // retrieves a user by email
 func (r *UserRepository) GetByEmail(email string) (*domain.User, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
 	defer cancel()
 
 	var user domain.User
@@ -125,7 +133,7 @@ func (r *UserRepository) GetByEmail(email string) (*domain.User, error) {
 
 // retrieves a user by username
 func (r *UserRepository) GetByUsername(username string) (*domain.User, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
 	defer cancel()
 
 	var user domain.User
@@ -142,7 +150,7 @@ func (r *UserRepository) GetByUsername(username string) (*domain.User, error) {
 
 // updates a user
 func (r *UserRepository) Update(user *domain.User) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
 	defer cancel()
 
 	user.UpdatedAt = time.Now()
@@ -157,7 +165,7 @@ func (r *UserRepository) Update(user *domain.User) error {
 
 // deletes a user by ID
 func (r *UserRepository) Delete(id primitive.ObjectID) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
 	defer cancel()
 
 	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
@@ -166,7 +174,7 @@ func (r *UserRepository) Delete(id primitive.ObjectID) error {
 
 // updates user profile information
 func (r *UserRepository) UpdateProfile(id primitive.ObjectID, updates map[string]interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
 	defer cancel()
 
 	updates["updated_at"] = time.Now()
@@ -181,7 +189,7 @@ func (r *UserRepository) UpdateProfile(id primitive.ObjectID, updates map[string
 
 // updates user password
 func (r *UserRepository) UpdatePassword(id primitive.ObjectID, password string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
 	defer cancel()
 
 	_, err := r.collection.UpdateOne(
@@ -197,7 +205,7 @@ func (r *UserRepository) UpdatePassword(id primitive.ObjectID, password string)
 
 // updates user role
 func (r *UserRepository) UpdateRole(id primitive.ObjectID, role string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
 	defer cancel()
 
 	_, err := r.collection.UpdateOne(
@@ -211,9 +219,74 @@ func (r *UserRepository) UpdateRole(id primitive.ObjectID, role string) error {
 	return err
 }
 
+// adds a user to the block list
+func (r *UserRepository) BlockUser(id, blockedID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{
+			"$addToSet": bson.M{"blocked_users": blockedID},
+			"$set":      bson.M{"updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+// removes a user from the block list
+func (r *UserRepository) UnblockUser(id, blockedID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{
+			"$pull": bson.M{"blocked_users": blockedID},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+func (r *UserRepository) UpdateNotificationPreferences(id primitive.ObjectID, preferences map[string]bool) error {
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{
+			"notification_preferences": preferences,
+			"updated_at":               time.Now(),
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
 // updates user profile picture
 func (r *UserRepository) UploadProfilePicture(id primitive.ObjectID, photo *domain.Photo) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(baseContext(r.ctx), 5*time.Second)
 	defer cancel()
 
 	_, err := r.collection.UpdateOne(
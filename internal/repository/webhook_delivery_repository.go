@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WebhookDeliveryRepository struct {
+	db         *database.MongoDB
+	collection *mongo.Collection
+}
+
+func NewWebhookDeliveryRepository(db *database.MongoDB) domain.WebhookDeliveryRepository {
+	collection := db.GetCollection("webhook_deliveries")
+
+	indexModels := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "webhook_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}},
+		},
+		{
+			// redeliveries carry no event id and may repeat
+			Keys: bson.D{{Key: "event_id", Value: 1}, {Key: "webhook_id", Value: 1}},
+			Options: options.Index().SetUnique(true).
+				SetPartialFilterExpression(bson.M{"event_id": bson.M{"$exists": true}}),
+		},
+	}
+	// indexes might already exist, so an error here is not fatal
+	_, _ = collection.Indexes().CreateMany(context.Background(), indexModels)
+
+	return &WebhookDeliveryRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+func (r *WebhookDeliveryRepository) Create(delivery *domain.WebhookDelivery) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.InsertOne(ctx, delivery)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		delivery.ID = oid
+	}
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) CreateOnce(delivery *domain.WebhookDelivery) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"event_id": delivery.EventID, "webhook_id": delivery.WebhookID}
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$setOnInsert": delivery}, options.Update().SetUpsert(true))
+	if err != nil {
+		// a concurrent upsert of the same delivery won the race
+		if mongo.IsDuplicateKeyError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+	if oid, ok := result.UpsertedID.(primitive.ObjectID); ok {
+		delivery.ID = oid
+	}
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) GetByID(id primitive.ObjectID) (*domain.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var delivery domain.WebhookDelivery
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&delivery)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("delivery not found")
+		}
+		return nil, fmt.Errorf("database error in GetByID: %w", err)
+	}
+	return &delivery, nil
+}
+
+func (r *WebhookDeliveryRepository) ListByWebhook(webhookID primitive.ObjectID, page, limit int) ([]*domain.WebhookDelivery, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	deliveries := []*domain.WebhookDelivery{}
+	filter := bson.M{"webhook_id": webhookID}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(page-1) * int64(limit))
+
+	curr, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find webhook deliveries: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	if err := curr.All(ctx, &deliveries); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return deliveries, total, nil
+}
+
+// pushes the next attempt of the oldest due delivery past the lease and returns it,
+// or nil when nothing is due. A worker that dies mid-send leaves the delivery to be retried.
+func (r *WebhookDeliveryRepository) ClaimDue(now time.Time, lease time.Duration) (*domain.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var delivery domain.WebhookDelivery
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{"status": domain.DeliveryPending, "next_attempt_at": bson.M{"$lte": now}},
+		bson.M{"$set": bson.M{"next_attempt_at": now.Add(lease)}},
+		options.FindOneAndUpdate().
+			SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+			SetReturnDocument(options.After),
+	).Decode(&delivery)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim webhook delivery: %w", err)
+	}
+	return &delivery, nil
+}
+
+func (r *WebhookDeliveryRepository) Update(delivery *domain.WebhookDelivery) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": delivery.ID}, bson.M{"$set": delivery})
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("delivery not found for update")
+	}
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) DeleteByWebhook(webhookID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(ctx, bson.M{"webhook_id": webhookID})
+	return err
+}
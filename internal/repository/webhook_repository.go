@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"Blog-API/internal/domain"
+	"Blog-API/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WebhookRepository struct {
+	db         *database.MongoDB
+	collection *mongo.Collection
+}
+
+func NewWebhookRepository(db *database.MongoDB) domain.WebhookRepository {
+	collection := db.GetCollection("webhooks")
+
+	indexModels := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "owner_id", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "events", Value: 1}, {Key: "active", Value: 1}},
+		},
+	}
+	// indexes might already exist, so an error here is not fatal
+	_, _ = collection.Indexes().CreateMany(context.Background(), indexModels)
+
+	return &WebhookRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+func (r *WebhookRepository) Create(webhook *domain.Webhook) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.InsertOne(ctx, webhook)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		webhook.ID = oid
+	}
+	return nil
+}
+
+func (r *WebhookRepository) GetByID(id primitive.ObjectID) (*domain.Webhook, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var webhook domain.Webhook
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&webhook)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("webhook not found")
+		}
+		return nil, fmt.Errorf("database error in GetByID: %w", err)
+	}
+	return &webhook, nil
+}
+
+func (r *WebhookRepository) ListByOwner(ownerID primitive.ObjectID) ([]*domain.Webhook, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	webhooks := []*domain.Webhook{}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	curr, err := r.collection.Find(ctx, bson.M{"owner_id": ownerID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhooks: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	if err := curr.All(ctx, &webhooks); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// active webhooks subscribed to the event that belong to the owner or are global
+func (r *WebhookRepository) FindSubscribed(event string, ownerID primitive.ObjectID) ([]*domain.Webhook, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	webhooks := []*domain.Webhook{}
+	filter := bson.M{
+		"events": event,
+		"active": true,
+		"$or": bson.A{
+			bson.M{"owner_id": ownerID},
+			bson.M{"global": true},
+		},
+	}
+	curr, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhooks: %w", err)
+	}
+	defer curr.Close(ctx)
+
+	if err := curr.All(ctx, &webhooks); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) Update(webhook *domain.Webhook) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": webhook.ID}, bson.M{"$set": webhook})
+	if err != nil {
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("webhook not found for update")
+	}
+	return nil
+}
+
+func (r *WebhookRepository) Delete(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errors.New("webhook not found for delete")
+	}
+	return nil
+}
+
+// counts a failed attempt and returns how many attempts in a row have failed
+func (r *WebhookRepository) RecordFailure(id primitive.ObjectID) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var webhook domain.Webhook
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{"_id": id},
+		bson.M{"$inc": bson.M{"consecutive_failures": 1}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&webhook)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return 0, errors.New("webhook not found")
+		}
+		return 0, fmt.Errorf("failed to record webhook failure: %w", err)
+	}
+	return webhook.ConsecutiveFailures, nil
+}
+
+func (r *WebhookRepository) ResetFailures(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "consecutive_failures": bson.M{"$ne": 0}},
+		bson.M{"$set": bson.M{"consecutive_failures": 0}},
+	)
+	return err
+}
+
+// turns off a webhook that keeps failing
+func (r *WebhookRepository) Disable(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"active": false, "disabled_at": now, "updated_at": now}},
+	)
+	return err
+}
@@ -2,20 +2,81 @@ package usecase
 
 import (
 	"Blog-API/internal/domain"
+	"Blog-API/pkg/highlight"
+	"Blog-API/pkg/mention"
+	"Blog-API/pkg/slug"
+	"context"
 	"errors"
+	"fmt"
+	"html"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type blogUseCase struct {
-	blogRepo domain.BlogRepository
-	userRepo domain.UserRepository
+	blogRepo      domain.BlogRepository
+	userRepo      domain.UserRepository
+	seriesRepo    domain.SeriesRepository
+	commentRepo   domain.CommentRepository
+	reactionRepo  domain.ReactionRepository
+	bookmarkRepo  domain.BookmarkRepository
+	tagRepo       domain.TagRepository
+	tags          domain.TagCanonicalizer
+	categories    domain.CategoryResolver
+	trending      domain.TrendingRanker
+	renderer      domain.ContentRenderer
+	policy        domain.CommentPolicy
+	reactionTypes []domain.ReactionType
+	notifier      domain.Notifier
+	publisher     domain.EventPublisher
+	tx            domain.Transactor
+	outbox        domain.OutboxRepository
 }
 
-func NewBlogUseCase(
-	blogRepo domain.BlogRepository, userRepo domain.UserRepository) domain.BlogUseCase {
-	return &blogUseCase{blogRepo: blogRepo, userRepo: userRepo}
+// everything the blog use case is built from, named so that callers wiring only a few
+// of them, e.g. tests, can leave the rest out
+type BlogUseCaseDeps struct {
+	BlogRepo      domain.BlogRepository
+	UserRepo      domain.UserRepository
+	SeriesRepo    domain.SeriesRepository
+	CommentRepo   domain.CommentRepository
+	ReactionRepo  domain.ReactionRepository
+	BookmarkRepo  domain.BookmarkRepository
+	TagRepo       domain.TagRepository
+	Tags          domain.TagCanonicalizer
+	Categories    domain.CategoryResolver
+	Trending      domain.TrendingRanker
+	Renderer      domain.ContentRenderer
+	Policy        domain.CommentPolicy
+	ReactionTypes []domain.ReactionType
+	Notifier      domain.Notifier
+	Publisher     domain.EventPublisher
+	Tx            domain.Transactor
+	Outbox        domain.OutboxRepository
+}
+
+func NewBlogUseCase(deps BlogUseCaseDeps) domain.BlogUseCase {
+	return &blogUseCase{
+		blogRepo:      deps.BlogRepo,
+		userRepo:      deps.UserRepo,
+		seriesRepo:    deps.SeriesRepo,
+		commentRepo:   deps.CommentRepo,
+		reactionRepo:  deps.ReactionRepo,
+		bookmarkRepo:  deps.BookmarkRepo,
+		tagRepo:       deps.TagRepo,
+		tags:          deps.Tags,
+		categories:    deps.Categories,
+		trending:      deps.Trending,
+		renderer:      deps.Renderer,
+		policy:        deps.Policy,
+		reactionTypes: deps.ReactionTypes,
+		notifier:      deps.Notifier,
+		publisher:     deps.Publisher,
+		tx:            deps.Tx,
+		outbox:        deps.Outbox,
+	}
 }
 
 func (uc *blogUseCase) CreateBlog(blog *domain.Blog, authorID primitive.ObjectID) error {
@@ -29,15 +90,50 @@ func (uc *blogUseCase) CreateBlog(blog *domain.Blog, authorID primitive.ObjectID
 	blog.AuthorUsername = author.Username
 	blog.CreatedAt = time.Now()
 	blog.UpdatedAt = time.Now()
-	// initialize slices and countr to ensure they are not nil
-	blog.Comments = []domain.Comment{}
-	blog.Likes = []string{}
-	blog.Dislikes = []string{}
+	// initialize counters
 	blog.ViewCount = 0
 	blog.LikeCount = 0
+	blog.ReactionCounts = map[string]int{}
 	blog.CommentCount = 0
 
-	return uc.blogRepo.Create(blog)
+	blog.SlugHistory = []string{}
+
+	if blog.Tags, err = uc.tags.Canonicalize(blog.Tags); err != nil {
+		return err
+	}
+	if blog.CategoryID != nil {
+		if blog.Breadcrumbs, err = uc.categoryPath(*blog.CategoryID); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.renderContent(blog); err != nil {
+		return err
+	}
+	uc.linkMentions(blog, authorID)
+	_, blog.NotifiedUsers = unnotifiedMentions(blog.Mentions, nil)
+
+	for attempt, minSuffix := 1, 1; ; attempt++ {
+		var suffix int
+		if blog.Slug, suffix, err = uc.uniqueSlug(blog.Title, blog.ID, minSuffix); err != nil {
+			return err
+		}
+		err = commitWithEvents(uc.tx, uc.outbox, func(ctx context.Context) error {
+			if err := uc.blogRepo.WithContext(ctx).Create(blog); err != nil {
+				return err
+			}
+			return uc.tagRepo.WithContext(ctx).IncrementUsage(tagUsageDeltas(nil, blog.Tags))
+		}, &domain.BlogCreated{Blog: blog})
+		if !isSlugConflict(err) || attempt == maxSlugAttempts {
+			break
+		}
+		minSuffix = suffix + 1
+	}
+	if err != nil {
+		return err
+	}
+	notifyMentions(uc.notifier, blog.Mentions, authorID, author.Username, blog.ID, nil)
+	return nil
 }
 
 func (uc *blogUseCase) GetBlog(id primitive.ObjectID) (*domain.Blog, error) {
@@ -46,12 +142,165 @@ func (uc *blogUseCase) GetBlog(id primitive.ObjectID) (*domain.Blog, error) {
 	if err != nil {
 		return nil, errors.New("blog not found")
 	}
+	blog.SeriesNav = uc.seriesNavigation(blog.ID)
+	blog.Breadcrumbs = uc.categoryBreadcrumbs(blog)
 	go uc.blogRepo.IncrementViewCount(id)
 	return blog, nil
 }
 
-func (uc *blogUseCase) GetAllBlogs(page, limit int, sort string) ([]*domain.Blog, int64, error) {
-	return uc.blogRepo.GetAll(page, limit, sort)
+// checks that a category picked for a blog exists and returns its breadcrumbs
+func (uc *blogUseCase) categoryPath(categoryID primitive.ObjectID) ([]domain.CategoryCrumb, error) {
+	crumbs, err := uc.categories.Breadcrumbs(categoryID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, errors.New("invalid category_id: category does not exist")
+		}
+		return nil, err
+	}
+	return crumbs, nil
+}
+
+// best effort: a blog whose category has gone missing is shown without breadcrumbs
+func (uc *blogUseCase) categoryBreadcrumbs(blog *domain.Blog) []domain.CategoryCrumb {
+	if blog.CategoryID == nil {
+		return nil
+	}
+	crumbs, err := uc.categories.Breadcrumbs(*blog.CategoryID)
+	if err != nil {
+		return nil
+	}
+	return crumbs
+}
+
+// looks a blog up by slug; old slugs resolve to the renamed blog so callers can redirect
+func (uc *blogUseCase) GetBlogBySlug(blogSlug string) (*domain.Blog, error) {
+	blog, err := uc.blogRepo.GetBySlug(blogSlug)
+	if err != nil {
+		return nil, errors.New("blog not found")
+	}
+	blog.SeriesNav = uc.seriesNavigation(blog.ID)
+	blog.Breadcrumbs = uc.categoryBreadcrumbs(blog)
+	go uc.blogRepo.IncrementViewCount(blog.ID)
+	return blog, nil
+}
+
+func (uc *blogUseCase) GetBlogByPermalink(username, blogSlug string) (*domain.Blog, error) {
+	blog, err := uc.blogRepo.GetBySlug(blogSlug)
+	if err != nil || blog.AuthorUsername != username {
+		return nil, errors.New("blog not found")
+	}
+	blog.SeriesNav = uc.seriesNavigation(blog.ID)
+	blog.Breadcrumbs = uc.categoryBreadcrumbs(blog)
+	go uc.blogRepo.IncrementViewCount(blog.ID)
+	return blog, nil
+}
+
+func (uc *blogUseCase) ListBlogs(params *domain.ListBlogParams, viewerID primitive.ObjectID, viewerRole string) ([]*domain.Blog, int64, *domain.BlogFacets, error) {
+	if err := uc.prepareListing(params, viewerID, viewerRole); err != nil {
+		return nil, 0, nil, err
+	}
+	if params.Facets {
+		return uc.blogRepo.ListWithFacets(params)
+	}
+	blogs, total, err := uc.blogRepo.List(params)
+	return blogs, total, nil, err
+}
+
+func (uc *blogUseCase) ListBlogsByCursor(params *domain.ListBlogParams, cursor string, count bool, viewerID primitive.ObjectID, viewerRole string) ([]*domain.Blog, *domain.CursorPage, error) {
+	if err := uc.prepareListing(params, viewerID, viewerRole); err != nil {
+		return nil, nil, err
+	}
+	if params.Facets {
+		return nil, nil, errors.New("invalid request: facets are only available with page numbers")
+	}
+	var after *domain.Cursor
+	if cursor != "" {
+		var err error
+		if after, err = domain.ParseCursor(cursor, params.SortBy); err != nil {
+			return nil, nil, err
+		}
+	}
+	return uc.blogRepo.ListByCursor(params, after, count)
+}
+
+// validates a listing and narrows it down to what the viewer may see
+func (uc *blogUseCase) prepareListing(params *domain.ListBlogParams, viewerID primitive.ObjectID, viewerRole string) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+	// the trash is private, so anyone but an admin only sees their own deleted blogs
+	if params.Status != domain.BlogStatusPublished && viewerRole != domain.RoleAdmin {
+		if viewerID.IsZero() {
+			return errors.New("forbidden: sign in to list deleted blogs")
+		}
+		params.OwnerID = &viewerID
+	}
+
+	if len(params.Tags) > 0 {
+		tags, err := uc.tags.Canonicalize(params.Tags)
+		if err != nil {
+			return err
+		}
+		params.Tags = tags
+		// blogs saved before a synonym was added still carry it
+		params.TagSynonyms = map[string][]string{}
+		for _, tag := range tags {
+			expanded, err := uc.tags.Expand([]string{tag})
+			if err != nil {
+				return err
+			}
+			params.TagSynonyms[tag] = expanded[1:]
+		}
+	}
+	return nil
+}
+
+const (
+	// characters of content shown around a search match
+	searchSnippetLength = 160
+	// excerpts shown per search result
+	maxSearchSnippets = 3
+)
+
+func (uc *blogUseCase) SearchBlogs(params *domain.ListBlogParams, viewerID primitive.ObjectID, viewerRole string) ([]*domain.Blog, int64, *domain.BlogFacets, error) {
+	if strings.TrimSpace(params.Query) == "" {
+		return nil, 0, nil, errors.New("invalid search: a query is required")
+	}
+	blogs, total, facets, err := uc.ListBlogs(params, viewerID, viewerRole)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	highlightMatches(blogs, params.Query)
+	return blogs, total, facets, nil
+}
+
+func (uc *blogUseCase) SearchBlogsByCursor(params *domain.ListBlogParams, cursor string, count bool, viewerID primitive.ObjectID, viewerRole string) ([]*domain.Blog, *domain.CursorPage, error) {
+	if strings.TrimSpace(params.Query) == "" {
+		return nil, nil, errors.New("invalid search: a query is required")
+	}
+	blogs, page, err := uc.ListBlogsByCursor(params, cursor, count, viewerID, viewerRole)
+	if err != nil {
+		return nil, nil, err
+	}
+	highlightMatches(blogs, params.Query)
+	return blogs, page, nil
+}
+
+// marks the query terms in the titles and adds content snippets around them
+func highlightMatches(blogs []*domain.Blog, query string) {
+	terms := highlight.Terms(query)
+	for _, blog := range blogs {
+		if blog.Search == nil {
+			blog.Search = &domain.SearchMatch{}
+		}
+		// blogs written before content was rendered only have the markdown source
+		content := blog.Content
+		if blog.ContentHTML != "" {
+			content = highlight.Text(blog.ContentHTML)
+		}
+		blog.Search.Title = highlight.Mark(blog.Title, terms)
+		blog.Search.Snippets = highlight.Snippets(content, terms, searchSnippetLength, maxSearchSnippets)
+	}
 }
 
 func (uc *blogUseCase) UpdateBlog(id primitive.ObjectID, blogUpdate *domain.Blog, userID primitive.ObjectID, userRole string) (*domain.Blog, error) {
@@ -59,15 +308,75 @@ func (uc *blogUseCase) UpdateBlog(id primitive.ObjectID, blogUpdate *domain.Blog
 	if err != nil {
 		return nil, errors.New("blog not found")
 	}
-	if originalBlog.AuthorID != userID && userRole != domain.RoleAdmin {
+	if !originalBlog.CanEdit(userID) && userRole != domain.RoleAdmin {
 		return nil, errors.New("forbidden: you are not authorized to update this post")
 	}
 
-	originalBlog.Title = blogUpdate.Title
-	originalBlog.Content = blogUpdate.Content
-	originalBlog.Tags = blogUpdate.Tags
+	titleChanged := blogUpdate.Title != "" && blogUpdate.Title != originalBlog.Title
+	if titleChanged {
+		originalBlog.Title = blogUpdate.Title
+	}
+	var newMentions []domain.Mention
+	contentChanged := blogUpdate.Content != "" && blogUpdate.Content != originalBlog.Content
+	if contentChanged {
+		// blogs saved before the notified users were kept have only notified their current mentions
+		notified := append(mentionedUserIDs(originalBlog.Mentions), originalBlog.NotifiedUsers...)
+		originalBlog.Content = blogUpdate.Content
+		if err := uc.renderContent(originalBlog); err != nil {
+			return nil, err
+		}
+		uc.linkMentions(originalBlog, userID)
+		newMentions, originalBlog.NotifiedUsers = unnotifiedMentions(originalBlog.Mentions, notified)
+	}
+	previousTags := originalBlog.Tags
+	if blogUpdate.Tags != nil {
+		if originalBlog.Tags, err = uc.tags.Canonicalize(blogUpdate.Tags); err != nil {
+			return nil, err
+		}
+	}
+	// a nil ObjectID removes the category, a nil pointer leaves it alone
+	if blogUpdate.CategoryID != nil {
+		if blogUpdate.CategoryID.IsZero() {
+			originalBlog.CategoryID = nil
+		} else if originalBlog.Breadcrumbs, err = uc.categoryPath(*blogUpdate.CategoryID); err != nil {
+			return nil, err
+		} else {
+			originalBlog.CategoryID = blogUpdate.CategoryID
+		}
+	}
 	originalBlog.UpdatedAt = time.Now()
 
+	previousSlug, previousHistory := originalBlog.Slug, originalBlog.SlugHistory
+	for attempt, minSuffix := 1, 1; ; attempt++ {
+		var suffix int
+		if titleChanged {
+			originalBlog.Slug, originalBlog.SlugHistory = previousSlug, previousHistory
+			if suffix, err = uc.reslug(originalBlog, minSuffix); err != nil {
+				return nil, err
+			}
+		}
+		err = commitWithEvents(uc.tx, uc.outbox, func(ctx context.Context) error {
+			if err := uc.blogRepo.WithContext(ctx).Update(originalBlog); err != nil {
+				return err
+			}
+			return uc.tagRepo.WithContext(ctx).IncrementUsage(tagUsageDeltas(previousTags, originalBlog.Tags))
+		}, &domain.BlogUpdated{Blog: originalBlog})
+		if !titleChanged || !isSlugConflict(err) || attempt == maxSlugAttempts {
+			break
+		}
+		minSuffix = suffix + 1
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// only people never mentioned in the blog before are notified
+	if len(newMentions) > 0 {
+		if editor, err := uc.userRepo.GetByID(userID); err == nil {
+			notifyMentions(uc.notifier, newMentions, userID, editor.Username, originalBlog.ID, nil)
+		}
+	}
+
 	return originalBlog, nil
 }
 
@@ -79,59 +388,347 @@ func (uc *blogUseCase) DeleteBlog(id primitive.ObjectID, userID primitive.Object
 	if blog.AuthorID != userID && userRole != domain.RoleAdmin {
 		return errors.New("forbidden: you are not authorized to delete this post")
 	}
-	return uc.blogRepo.Delete(id)
+	// trashed blogs no longer count towards their tags
+	return commitWithEvents(uc.tx, uc.outbox, func(ctx context.Context) error {
+		if err := uc.blogRepo.WithContext(ctx).Delete(id); err != nil {
+			return err
+		}
+		return uc.tagRepo.WithContext(ctx).IncrementUsage(tagUsageDeltas(blog.Tags, nil))
+	}, &domain.BlogDeleted{Blog: blog})
+}
+
+// takes a blog out of the trash; owners can restore their own posts, admins anyone's
+func (uc *blogUseCase) RestoreBlog(id primitive.ObjectID, userID primitive.ObjectID, userRole string) (*domain.Blog, error) {
+	blog, err := uc.blogRepo.GetDeletedByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if blog.AuthorID != userID && userRole != domain.RoleAdmin {
+		return nil, errors.New("forbidden: you are not authorized to restore this post")
+	}
+	blog.DeletedAt = nil
+	err = commitWithEvents(uc.tx, uc.outbox, func(ctx context.Context) error {
+		if err := uc.blogRepo.WithContext(ctx).Restore(id); err != nil {
+			return err
+		}
+		return uc.tagRepo.WithContext(ctx).IncrementUsage(tagUsageDeltas(nil, blog.Tags))
+	}, &domain.BlogRestored{Blog: blog})
+	if err != nil {
+		return nil, err
+	}
+	return blog, nil
+}
+
+func (uc *blogUseCase) GetTrash(userID primitive.ObjectID, page, limit int) ([]*domain.Blog, int64, error) {
+	return uc.blogRepo.GetTrash(userID, page, limit)
+}
+
+// blogs rendered per transaction by BackfillRendered
+const backfillBatchSize = 100
+
+// blogs written before rendering on save have no HTML, excerpt or reading time. Their
+// mentions are linked too, without notifying anyone, and search re-syncs them.
+func (uc *blogUseCase) BackfillRendered() (int, error) {
+	rendered := 0
+	after := primitive.NilObjectID
+	for {
+		blogs, err := uc.blogRepo.ListUnrendered(after, backfillBatchSize)
+		if err != nil {
+			return rendered, err
+		}
+		if len(blogs) == 0 {
+			return rendered, nil
+		}
+
+		ids := make([]primitive.ObjectID, 0, len(blogs))
+		for _, blog := range blogs {
+			if err := uc.renderContent(blog); err != nil {
+				return rendered, fmt.Errorf("blog %s: %w", blog.ID.Hex(), err)
+			}
+			uc.linkMentions(blog, blog.AuthorID)
+			ids = append(ids, blog.ID)
+		}
+		err = commitWithEvents(uc.tx, uc.outbox, func(ctx context.Context) error {
+			blogRepo := uc.blogRepo.WithContext(ctx)
+			for _, blog := range blogs {
+				if err := blogRepo.SetRendered(blog); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, blogsChangedEvents(ids)...)
+		if err != nil {
+			return rendered, err
+		}
+		rendered += len(blogs)
+		after = blogs[len(blogs)-1].ID
+	}
+}
+
+// permanently deletes blogs that have been in the trash longer than retention
+func (uc *blogUseCase) PurgeTrash(retention time.Duration) (int, error) {
+	expired, err := uc.blogRepo.ListDeletedBefore(time.Now().Add(-retention))
+	if err != nil {
+		return 0, err
+	}
+	// the blog goes last, so a failure half way leaves it in the trash for the next run
+	// to finish rather than leaving orphans behind
+	purged := 0
+	for _, id := range expired {
+		if err := uc.seriesRepo.RemoveBlog(id); err != nil {
+			return purged, err
+		}
+		if err := uc.commentRepo.DeleteByBlog(id); err != nil {
+			return purged, err
+		}
+		if err := uc.reactionRepo.DeleteByBlog(id); err != nil {
+			return purged, err
+		}
+		if err := uc.bookmarkRepo.DeleteByBlog(id); err != nil {
+			return purged, err
+		}
+		if err := uc.blogRepo.Purge(id); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
 }
 
 func (uc *blogUseCase) AddComment(blogID primitive.ObjectID, comment *domain.Comment) error {
+	blog, err := uc.blogRepo.GetByID(blogID)
+	if err != nil {
+		return errors.New("blog not found")
+	}
 	author, err := uc.userRepo.GetByID(comment.AuthorID)
 	if err != nil {
 		return errors.New("comment author not found")
 	}
+
+	// replies hang off their parent, at any depth
+	if comment.ParentID != nil {
+		parent, err := uc.commentRepo.GetByID(*comment.ParentID)
+		if err != nil || parent.BlogID != blogID {
+			return errors.New("parent comment not found")
+		}
+		comment.Depth = parent.Depth + 1
+	}
+
+	// the moderation policy decides whether the comment is published or held back
+	status, err := uc.policy.Evaluate(blog, comment, author)
+	if err != nil {
+		return err
+	}
+
 	comment.ID = primitive.NewObjectID()
+	comment.BlogID = blogID
+	comment.BlogAuthorID = blog.AuthorID
 	comment.AuthorUsername = author.Username
+	comment.Mentions, comment.ContentHTML = uc.linkCommentMentions(comment.Content, author.ID)
+	comment.Status = status
+	comment.ReplyCount = 0
 	comment.CreatedAt = time.Now()
 	comment.UpdatedAt = time.Now()
-	return uc.blogRepo.AddComment(blogID, comment)
+
+	err = commitWithEvents(uc.tx, uc.outbox, func(ctx context.Context) error {
+		commentRepo := uc.commentRepo.WithContext(ctx)
+		if err := commentRepo.Create(comment); err != nil {
+			return err
+		}
+		// held comments only count once a moderator approves them
+		if !comment.IsApproved() {
+			return nil
+		}
+		if comment.ParentID != nil {
+			if err := commentRepo.IncrementReplyCount(*comment.ParentID, 1); err != nil {
+				return err
+			}
+		}
+		return uc.blogRepo.WithContext(ctx).IncrementCommentCount(blogID, 1)
+	}, &domain.CommentAdded{Comment: comment, BlogAuthorID: blog.AuthorID})
+	if err != nil {
+		return err
+	}
+
+	if comment.IsApproved() {
+		announceComment(uc.notifier, uc.commentRepo, comment)
+		uc.publisher.Publish(domain.BlogTopic(blogID), domain.EventComment, comment)
+	}
+	return nil
 }
 
-func (uc *blogUseCase) LikeBlog(blogID primitive.ObjectID, userID string) error {
+func (uc *blogUseCase) GetComments(blogID primitive.ObjectID, parentID *primitive.ObjectID, viewerID primitive.ObjectID, viewerRole string, sort string, page, limit int) ([]*domain.Comment, int64, error) {
 	blog, err := uc.blogRepo.GetByID(blogID)
 	if err != nil {
-		return errors.New("blog not found")
+		return nil, 0, errors.New("blog not found")
 	}
-	isLiked := containsString(blog.Likes, userID)
-	isDisliked := containsString(blog.Dislikes, userID)
+	canModerate := domain.IsModeratorRole(viewerRole) || (!viewerID.IsZero() && blog.AuthorID == viewerID)
+	return uc.commentRepo.ListByBlog(blogID, parentID, viewerID, canModerate, sort, page, limit)
+}
 
-	if isLiked {
-		return uc.blogRepo.RemoveLike(blogID, userID)
+func (uc *blogUseCase) GetCommentsByCursor(blogID primitive.ObjectID, parentID *primitive.ObjectID, viewerID primitive.ObjectID, viewerRole string, sort string, cursor string, limit int, count bool) ([]*domain.Comment, *domain.CursorPage, error) {
+	blog, err := uc.blogRepo.GetByID(blogID)
+	if err != nil {
+		return nil, nil, errors.New("blog not found")
+	}
+	var after *domain.Cursor
+	if cursor != "" {
+		if after, err = domain.ParseCursor(cursor, sort); err != nil {
+			return nil, nil, err
+		}
 	}
+	canModerate := domain.IsModeratorRole(viewerRole) || (!viewerID.IsZero() && blog.AuthorID == viewerID)
+	return uc.commentRepo.ListByBlogCursor(blogID, parentID, viewerID, canModerate, sort, after, limit, count)
+}
 
-	if isDisliked {
-		if err := uc.blogRepo.RemoveDislike(blogID, userID); err != nil {
+// reacts to a blog; reacting the same way again takes the reaction back, reacting
+// differently replaces it. Every step is a single conditional write and the counters
+// move by what those writes actually changed, so concurrent toggles never double count.
+func (uc *blogUseCase) ReactToBlog(blogID, userID primitive.ObjectID, reactionType string) (*domain.ReactionState, error) {
+	if !uc.isReactionType(reactionType) {
+		return nil, errors.New("invalid reaction type")
+	}
+	blog, err := uc.blogRepo.GetByID(blogID)
+	if err != nil {
+		return nil, errors.New("blog not found")
+	}
+	user, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	var state *domain.ReactionState
+	err = uc.tx.WithTransaction(func(ctx context.Context) error {
+		reactionRepo := uc.reactionRepo.WithContext(ctx)
+		deltas := map[string]int{}
+		myReaction := ""
+
+		// only deletes when the user currently has this very reaction
+		removed, err := reactionRepo.Delete(blogID, userID, reactionType)
+		if err != nil {
 			return err
 		}
+		if removed != nil {
+			deltas[reactionType]--
+		} else {
+			now := time.Now()
+			previous, err := reactionRepo.Upsert(&domain.Reaction{
+				BlogID:       blogID,
+				UserID:       userID,
+				Username:     user.Username,
+				ReactionType: reactionType,
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			})
+			if err != nil {
+				return err
+			}
+			if previous != nil {
+				deltas[previous.ReactionType]--
+			}
+			deltas[reactionType]++
+			myReaction = reactionType
+		}
+
+		counts, err := uc.blogRepo.WithContext(ctx).IncrementReactionCounts(blogID, deltas)
+		if err != nil {
+			return err
+		}
+		state = &domain.ReactionState{BlogID: blogID, MyReaction: myReaction, Counts: uc.reactionCounts(counts)}
+		return uc.outbox.WithContext(ctx).Append(&domain.ReactionChanged{
+			Counts:       domain.ReactionCounts{BlogID: blogID, Counts: state.Counts},
+			BlogAuthorID: blog.AuthorID,
+			UserID:       userID,
+		})
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return uc.blogRepo.AddLike(blogID, userID)
+	uc.publisher.Publish(domain.BlogTopic(blogID), domain.EventReactions, domain.ReactionCounts{BlogID: blogID, Counts: state.Counts})
+	if state.MyReaction == domain.ReactionLike {
+		uc.notifier.Notify(&domain.Notification{
+			RecipientID:   blog.AuthorID,
+			Type:          domain.NotificationLike,
+			ActorID:       user.ID,
+			ActorUsername: user.Username,
+			BlogID:        blogID,
+		})
+	}
+	return state, nil
 }
 
-func (uc *blogUseCase) DislikeBlog(blogID primitive.ObjectID, userID string) error {
+// lists who reacted to a blog, with the counts per type and the viewer's own reaction
+func (uc *blogUseCase) GetReactions(blogID, viewerID primitive.ObjectID, reactionType string, page, limit int) ([]*domain.Reaction, int64, *domain.ReactionSummary, error) {
+	if reactionType != "" && !uc.isReactionType(reactionType) {
+		return nil, 0, nil, errors.New("invalid reaction type")
+	}
 	blog, err := uc.blogRepo.GetByID(blogID)
 	if err != nil {
-		return errors.New("blog not found")
+		return nil, 0, nil, errors.New("blog not found")
+	}
+	reactions, total, err := uc.reactionRepo.ListByBlog(blogID, reactionType, page, limit)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	summary := &domain.ReactionSummary{Counts: uc.reactionCounts(blog.ReactionCounts), Types: uc.reactionTypes}
+	if !viewerID.IsZero() {
+		if own, err := uc.reactionRepo.GetByBlogAndUser(blogID, viewerID); err == nil {
+			summary.MyReaction = own.ReactionType
+		}
 	}
-	isLiked := containsString(blog.Likes, userID)
-	isDisliked := containsString(blog.Dislikes, userID)
+	return reactions, total, summary, nil
+}
 
-	if isDisliked {
-		return uc.blogRepo.RemoveDislike(blogID, userID)
+// sets the bookmarked-by-me flag of each blog for a logged in viewer
+func (uc *blogUseCase) MarkBookmarked(viewerID primitive.ObjectID, blogs ...*domain.Blog) {
+	if viewerID.IsZero() || len(blogs) == 0 {
+		return
 	}
-	if isLiked {
-		if err := uc.blogRepo.RemoveLike(blogID, userID); err != nil {
-			return err
+	ids := make([]primitive.ObjectID, 0, len(blogs))
+	for _, blog := range blogs {
+		ids = append(ids, blog.ID)
+	}
+	bookmarked, err := uc.bookmarkRepo.BookmarkedBlogIDs(viewerID, ids)
+	if err != nil {
+		// the flag is a convenience, leave it unset rather than fail the request
+		return
+	}
+	for _, blog := range blogs {
+		flag := bookmarked[blog.ID]
+		blog.BookmarkedByMe = &flag
+	}
+}
+
+// usage count changes for a blog whose tags went from before to after
+func tagUsageDeltas(before, after []string) map[string]int {
+	deltas := map[string]int{}
+	for _, tag := range before {
+		deltas[tag]--
+	}
+	for _, tag := range after {
+		deltas[tag]++
+	}
+	return deltas
+}
+
+func (uc *blogUseCase) isReactionType(name string) bool {
+	for _, t := range uc.reactionTypes {
+		if t.Name == name {
+			return true
 		}
 	}
-	return uc.blogRepo.AddDislike(blogID, userID)
+	return false
+}
+
+// counts of every configured reaction type, including the ones nobody used yet
+func (uc *blogUseCase) reactionCounts(stored map[string]int) map[string]int {
+	counts := make(map[string]int, len(uc.reactionTypes))
+	for _, t := range uc.reactionTypes {
+		counts[t.Name] = stored[t.Name]
+	}
+	return counts
 }
 
 func (uc *blogUseCase) SearchBlogsByTitle(title string, page, limit int) ([]*domain.Blog, int64, error) {
@@ -143,15 +740,41 @@ func (uc *blogUseCase) SearchBlogsByAuthor(author string, page, limit int) ([]*d
 }
 
 func (uc *blogUseCase) FilterBlogsByTags(tags []string, page, limit int) ([]*domain.Blog, int64, error) {
-	return uc.blogRepo.FilterByTags(tags, page, limit)
+	blogs, total, _, err := uc.ListBlogs(&domain.ListBlogParams{Page: page, Limit: limit, Tags: tags}, primitive.NilObjectID, "")
+	return blogs, total, err
 }
 
 func (uc *blogUseCase) FilterBlogsByDate(startDate, endDate time.Time, page, limit int) ([]*domain.Blog, int64, error) {
-	return uc.blogRepo.FilterByDate(startDate, endDate, page, limit)
+	params := &domain.ListBlogParams{Page: page, Limit: limit, From: &startDate, To: &endDate}
+	blogs, total, _, err := uc.ListBlogs(params, primitive.NilObjectID, "")
+	return blogs, total, err
 }
 
-func (uc *blogUseCase) GetPopularBlogs(limit int) ([]*domain.Blog, error) {
-	return uc.blogRepo.GetPopular(limit)
+// the blogs of a trending ranking in rank order; ones trashed since it was computed are left out
+func (uc *blogUseCase) GetPopularBlogs(window string, limit int) (*domain.TrendingRanking, []*domain.Blog, error) {
+	ranking, err := uc.trending.Ranking(window, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	ids := make([]primitive.ObjectID, 0, len(ranking.Blogs))
+	for _, entry := range ranking.Blogs {
+		ids = append(ids, entry.BlogID)
+	}
+	found, err := uc.blogRepo.GetByIDs(ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	byID := make(map[primitive.ObjectID]*domain.Blog, len(found))
+	for _, blog := range found {
+		byID[blog.ID] = blog
+	}
+	blogs := make([]*domain.Blog, 0, len(ids))
+	for _, id := range ids {
+		if blog, ok := byID[id]; ok {
+			blogs = append(blogs, blog)
+		}
+	}
+	return ranking, blogs, nil
 }
 
 func (uc *blogUseCase) DeleteComment(blogID, commentID primitive.ObjectID, userID primitive.ObjectID) error {
@@ -163,53 +786,145 @@ func (uc *blogUseCase) DeleteComment(blogID, commentID primitive.ObjectID, userI
 	if err != nil {
 		return errors.New("user not found")
 	}
-	var commentAuthorID primitive.ObjectID
-	found := false
-	for _, c := range blog.Comments {
-		if c.ID == commentID {
-			commentAuthorID = c.AuthorID
-			found = true
-			break
-		}
-	}
-
-	if !found {
+	comment, err := uc.commentRepo.GetByID(commentID)
+	if err != nil || comment.BlogID != blogID || comment.Deleted {
 		return errors.New("comment not found")
 	}
 
-	isCommentAuthor := commentAuthorID == userID
+	isCommentAuthor := comment.AuthorID == userID
 	isBlogAuthor := blog.AuthorID == userID
-	isAdmin := user.Role == domain.RoleAdmin
+	isModerator := domain.IsModeratorRole(user.Role)
 
-	if !isCommentAuthor && !isBlogAuthor && !isAdmin {
-		return errors.New("forbideen: you are not authorized to delete this comment")
+	if !isCommentAuthor && !isBlogAuthor && !isModerator {
+		return errors.New("forbidden: you are not authorized to delete this comment")
 	}
 
-	return uc.blogRepo.DeleteComment(blogID, commentID)
+	// keep a placeholder while replies still point at the comment
+	if comment.ReplyCount > 0 {
+		if err := uc.commentRepo.MarkDeleted(commentID); err != nil {
+			return err
+		}
+	} else {
+		if err := uc.commentRepo.Delete(commentID); err != nil {
+			return err
+		}
+		if comment.ParentID != nil && comment.IsApproved() {
+			if err := uc.commentRepo.IncrementReplyCount(*comment.ParentID, -1); err != nil {
+				return err
+			}
+		}
+	}
+	if !comment.IsApproved() {
+		return nil
+	}
+	return uc.blogRepo.IncrementCommentCount(blogID, -1)
 }
 
 func (uc *blogUseCase) UpdateComment(blogID, commentID primitive.ObjectID, content string, userID primitive.ObjectID) error {
 	// Only the original comment author can update their comment.
-	blog, err := uc.blogRepo.GetByID(blogID)
-	if err != nil {
+	if _, err := uc.blogRepo.GetByID(blogID); err != nil {
 		return errors.New("blog not found")
 	}
-	var commentAuthorID primitive.ObjectID
-	found := false
-	for _, c := range blog.Comments {
-		if c.ID == commentID {
-			commentAuthorID = c.AuthorID
-			found = true
-			break
-		}
-	}
-	if !found {
+	comment, err := uc.commentRepo.GetByID(commentID)
+	if err != nil || comment.BlogID != blogID || comment.Deleted {
 		return errors.New("comment not found")
 	}
-	if commentAuthorID != userID {
+	if comment.AuthorID != userID {
 		return errors.New("forbidden: you are not the author of this comment")
 	}
-	return uc.blogRepo.UpdateComment(blogID, commentID, content)
+
+	mentions, contentHTML := uc.linkCommentMentions(content, userID)
+	// mentions are notified once the comment is published, so pending edits notify no one yet
+	var newMentions []domain.Mention
+	notified := comment.NotifiedUsers
+	if comment.IsApproved() {
+		notified = append(mentionedUserIDs(comment.Mentions), comment.NotifiedUsers...)
+		newMentions, notified = unnotifiedMentions(mentions, notified)
+	}
+	if err := uc.commentRepo.UpdateContent(commentID, content, contentHTML, mentions, notified); err != nil {
+		return err
+	}
+	notifyMentions(uc.notifier, newMentions, userID, comment.AuthorUsername, blogID, &commentID)
+	return nil
+}
+
+// generates a slug for title that no other blog uses, adding a numeric suffix of at least
+// minSuffix on collision; returns the slug and the suffix
+func (uc *blogUseCase) uniqueSlug(title string, blogID primitive.ObjectID, minSuffix int) (string, int, error) {
+	base := slug.Make(title)
+	if base == "" {
+		base = "post"
+	}
+	for n := minSuffix; ; n++ {
+		candidate := slug.WithSuffix(base, n)
+		taken, err := uc.blogRepo.SlugTaken(candidate, blogID)
+		if err != nil {
+			return "", 0, err
+		}
+		if !taken {
+			return candidate, n, nil
+		}
+	}
+}
+
+// a slug found free can still be taken by another blog before the write lands; the
+// unique index then rejects the write and it is retried with the next suffix
+const maxSlugAttempts = 5
+
+func isSlugConflict(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "slug already exists")
+}
+
+// moves the current slug into the history and derives a new one from the title,
+// returning the collision suffix used
+func (uc *blogUseCase) reslug(blog *domain.Blog, minSuffix int) (int, error) {
+	newSlug, suffix, err := uc.uniqueSlug(blog.Title, blog.ID, minSuffix)
+	if err != nil {
+		return 0, err
+	}
+	if newSlug == blog.Slug {
+		return suffix, nil
+	}
+
+	history := []string{}
+	for _, old := range blog.SlugHistory {
+		if old != newSlug {
+			history = append(history, old)
+		}
+	}
+	if blog.Slug != "" {
+		history = append(history, blog.Slug)
+	}
+	blog.Slug = newSlug
+	blog.SlugHistory = history
+	return suffix, nil
+}
+
+// renders the markdown content and caches the HTML and derived fields on the blog
+func (uc *blogUseCase) renderContent(blog *domain.Blog) error {
+	rendered, err := uc.renderer.Render(blog.Content)
+	if err != nil {
+		return errors.New("failed to render blog content")
+	}
+	blog.ContentHTML = rendered.HTML
+	blog.Excerpt = rendered.Excerpt
+	blog.WordCount = rendered.WordCount
+	blog.ReadingTime = rendered.ReadingTime
+	blog.TOC = rendered.TOC
+	return nil
+}
+
+// resolves the blog's mentions and turns them into profile links in the rendered HTML
+func (uc *blogUseCase) linkMentions(blog *domain.Blog, authorID primitive.ObjectID) {
+	blog.Mentions = resolveMentions(uc.userRepo, blog.ContentHTML, authorID)
+	blog.ContentHTML = mention.LinkHTML(blog.ContentHTML, mentionedUsernames(blog.Mentions))
+}
+
+// comments are plain text: escape them and link the resolved mentions
+func (uc *blogUseCase) linkCommentMentions(content string, authorID primitive.ObjectID) ([]domain.Mention, string) {
+	escaped := html.EscapeString(content)
+	mentions := resolveMentions(uc.userRepo, escaped, authorID)
+	return mentions, mention.LinkHTML(escaped, mentionedUsernames(mentions))
 }
 
 // helper function
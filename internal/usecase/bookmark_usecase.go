@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"Blog-API/internal/domain"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// upper bound on the bookmarks a reorder request may name
+const maxReorderItems = 1000
+
+type bookmarkUseCase struct {
+	bookmarkRepo    domain.BookmarkRepository
+	readingListRepo domain.ReadingListRepository
+	blogRepo        domain.BlogRepository
+	userRepo        domain.UserRepository
+}
+
+func NewBookmarkUseCase(bookmarkRepo domain.BookmarkRepository, readingListRepo domain.ReadingListRepository,
+	blogRepo domain.BlogRepository, userRepo domain.UserRepository) domain.BookmarkUseCase {
+	return &bookmarkUseCase{
+		bookmarkRepo:    bookmarkRepo,
+		readingListRepo: readingListRepo,
+		blogRepo:        blogRepo,
+		userRepo:        userRepo,
+	}
+}
+
+func (uc *bookmarkUseCase) AddBookmark(userID, blogID primitive.ObjectID) (*domain.Bookmark, error) {
+	return uc.save(userID, nil, blogID)
+}
+
+func (uc *bookmarkUseCase) RemoveBookmark(userID, blogID primitive.ObjectID) error {
+	return uc.bookmarkRepo.Remove(userID, nil, blogID)
+}
+
+func (uc *bookmarkUseCase) GetBookmarks(userID primitive.ObjectID, cursor string, limit int, count bool) ([]*domain.Bookmark, *domain.CursorPage, error) {
+	return uc.page(userID, nil, cursor, limit, count)
+}
+
+func (uc *bookmarkUseCase) ReorderBookmarks(userID primitive.ObjectID, blogIDs []primitive.ObjectID) error {
+	return uc.reorder(userID, nil, blogIDs)
+}
+
+func (uc *bookmarkUseCase) CreateReadingList(userID primitive.ObjectID, req *domain.CreateReadingListRequest) (*domain.ReadingList, error) {
+	owner, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	now := time.Now()
+	list := &domain.ReadingList{
+		ID:            primitive.NewObjectID(),
+		OwnerID:       owner.ID,
+		OwnerUsername: owner.Username,
+		Name:          strings.TrimSpace(req.Name),
+		Description:   req.Description,
+		Public:        req.Public,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if list.Name == "" {
+		return nil, errors.New("invalid request: name is required")
+	}
+	if err := uc.readingListRepo.Create(list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// lists the public reading lists of the named user, all of them when it is the viewer.
+// Without a username the viewer's own lists are returned.
+func (uc *bookmarkUseCase) GetReadingLists(username string, viewerID primitive.ObjectID) ([]*domain.ReadingList, error) {
+	if username == "" {
+		if viewerID.IsZero() {
+			return nil, errors.New("invalid request: username is required")
+		}
+		return uc.readingListRepo.ListByOwner(viewerID, false)
+	}
+	owner, err := uc.userRepo.GetByUsername(username)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	return uc.readingListRepo.ListByOwner(owner.ID, owner.ID != viewerID)
+}
+
+// private lists are only visible to their owner and look as if they did not exist to anyone else
+func (uc *bookmarkUseCase) GetReadingList(id, viewerID primitive.ObjectID, cursor string, limit int, count bool) (*domain.ReadingList, []*domain.Bookmark, *domain.CursorPage, error) {
+	list, err := uc.readingListRepo.GetByID(id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !list.Public && list.OwnerID != viewerID {
+		return nil, nil, nil, errors.New("reading list not found")
+	}
+	items, page, err := uc.page(list.OwnerID, &list.ID, cursor, limit, count)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return list, items, page, nil
+}
+
+func (uc *bookmarkUseCase) UpdateReadingList(id, userID primitive.ObjectID, req *domain.UpdateReadingListRequest) (*domain.ReadingList, error) {
+	list, err := uc.ownedList(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.New("invalid request: name is required")
+		}
+		list.Name = name
+	}
+	if req.Description != nil {
+		list.Description = *req.Description
+	}
+	if req.Public != nil {
+		list.Public = *req.Public
+	}
+	list.UpdatedAt = time.Now()
+
+	if err := uc.readingListRepo.Update(list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (uc *bookmarkUseCase) DeleteReadingList(id, userID primitive.ObjectID) error {
+	if _, err := uc.ownedList(id, userID); err != nil {
+		return err
+	}
+	if err := uc.readingListRepo.Delete(id); err != nil {
+		return err
+	}
+	return uc.bookmarkRepo.DeleteByList(id)
+}
+
+func (uc *bookmarkUseCase) AddToReadingList(id, userID, blogID primitive.ObjectID) (*domain.Bookmark, error) {
+	if _, err := uc.ownedList(id, userID); err != nil {
+		return nil, err
+	}
+	return uc.save(userID, &id, blogID)
+}
+
+func (uc *bookmarkUseCase) RemoveFromReadingList(id, userID, blogID primitive.ObjectID) error {
+	if _, err := uc.ownedList(id, userID); err != nil {
+		return err
+	}
+	return uc.bookmarkRepo.Remove(userID, &id, blogID)
+}
+
+func (uc *bookmarkUseCase) ReorderReadingList(id, userID primitive.ObjectID, blogIDs []primitive.ObjectID) error {
+	if _, err := uc.ownedList(id, userID); err != nil {
+		return err
+	}
+	return uc.reorder(userID, &id, blogIDs)
+}
+
+// appends a blog to the bookmarks or to a reading list
+func (uc *bookmarkUseCase) save(userID primitive.ObjectID, listID *primitive.ObjectID, blogID primitive.ObjectID) (*domain.Bookmark, error) {
+	blog, err := uc.blogRepo.GetByID(blogID)
+	if err != nil {
+		return nil, errors.New("blog not found")
+	}
+
+	now := time.Now()
+	bookmark := &domain.Bookmark{
+		ID:     primitive.NewObjectID(),
+		UserID: userID,
+		ListID: listID,
+		BlogID: blog.ID,
+		// reordering numbers positions from zero, so the clock keeps new entries last
+		Position:  now.UnixNano(),
+		CreatedAt: now,
+		Blog:      blog,
+	}
+	if err := uc.bookmarkRepo.Add(bookmark); err != nil {
+		return nil, err
+	}
+	return bookmark, nil
+}
+
+// returns the page of saved blogs next to the cursor, the first page without one.
+// Blogs that were deleted since they were saved are skipped.
+func (uc *bookmarkUseCase) page(userID primitive.ObjectID, listID *primitive.ObjectID, cursor string, limit int, count bool) ([]*domain.Bookmark, *domain.CursorPage, error) {
+	var after *domain.Cursor
+	if cursor != "" {
+		var err error
+		if after, err = domain.ParseCursor(cursor, domain.BookmarkSortPosition); err != nil {
+			return nil, nil, err
+		}
+	}
+	return uc.bookmarkRepo.List(userID, listID, after, limit, count)
+}
+
+// moves the named blogs to the front in the given order, keeping the rest after them
+func (uc *bookmarkUseCase) reorder(userID primitive.ObjectID, listID *primitive.ObjectID, blogIDs []primitive.ObjectID) error {
+	if len(blogIDs) > maxReorderItems {
+		return fmt.Errorf("invalid request: at most %d blogs can be reordered at once", maxReorderItems)
+	}
+	current, err := uc.bookmarkRepo.ListBlogIDs(userID, listID)
+	if err != nil {
+		return err
+	}
+	saved := make(map[primitive.ObjectID]bool, len(current))
+	for _, id := range current {
+		saved[id] = true
+	}
+
+	order := make([]primitive.ObjectID, 0, len(current))
+	seen := make(map[primitive.ObjectID]bool, len(blogIDs))
+	for _, id := range blogIDs {
+		if !saved[id] {
+			return errors.New("invalid request: blog " + id.Hex() + " is not saved here")
+		}
+		if seen[id] {
+			return errors.New("invalid request: blog " + id.Hex() + " is listed twice")
+		}
+		seen[id] = true
+		order = append(order, id)
+	}
+	for _, id := range current {
+		if !seen[id] {
+			order = append(order, id)
+		}
+	}
+	return uc.bookmarkRepo.SetOrder(userID, listID, order)
+}
+
+func (uc *bookmarkUseCase) ownedList(id, userID primitive.ObjectID) (*domain.ReadingList, error) {
+	list, err := uc.readingListRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if list.OwnerID != userID {
+		// other people's lists are either public and read-only or not visible at all
+		if list.Public {
+			return nil, errors.New("forbidden: you do not own this reading list")
+		}
+		return nil, errors.New("reading list not found")
+	}
+	return list, nil
+}
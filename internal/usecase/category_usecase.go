@@ -0,0 +1,294 @@
+package usecase
+
+import (
+	"Blog-API/internal/domain"
+	"Blog-API/pkg/slug"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type categoryUseCase struct {
+	categoryRepo domain.CategoryRepository
+	blogRepo     domain.BlogRepository
+	tx           domain.Transactor
+	outbox       domain.OutboxRepository
+}
+
+func NewCategoryUseCase(categoryRepo domain.CategoryRepository, blogRepo domain.BlogRepository, tx domain.Transactor, outbox domain.OutboxRepository) domain.CategoryUseCase {
+	return &categoryUseCase{
+		categoryRepo: categoryRepo,
+		blogRepo:     blogRepo,
+		tx:           tx,
+		outbox:       outbox,
+	}
+}
+
+// returns the top level categories with their subcategories nested below them
+func (uc *categoryUseCase) GetCategoryTree() ([]*domain.Category, error) {
+	categories, err := uc.categoryRepo.List()
+	if err != nil {
+		return nil, err
+	}
+	return buildCategoryTree(categories, nil), nil
+}
+
+func (uc *categoryUseCase) GetCategory(idOrSlug string, page, limit int) (*domain.Category, []*domain.Blog, int64, error) {
+	category, err := uc.lookup(idOrSlug)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if category.Breadcrumbs, err = uc.crumbs(category); err != nil {
+		return nil, nil, 0, err
+	}
+
+	descendants, err := uc.categoryRepo.ListDescendants(category.ID)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	category.Children = buildCategoryTree(descendants, &category.ID)
+
+	ids := []primitive.ObjectID{category.ID}
+	for _, descendant := range descendants {
+		ids = append(ids, descendant.ID)
+	}
+	blogs, total, err := uc.blogRepo.ListByCategories(ids, page, limit)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return category, blogs, total, nil
+}
+
+func (uc *categoryUseCase) CreateCategory(req *domain.CreateCategoryRequest) (*domain.Category, error) {
+	categorySlug, err := categorySlug(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	category := &domain.Category{
+		Name:        strings.TrimSpace(req.Name),
+		Slug:        categorySlug,
+		Description: strings.TrimSpace(req.Description),
+		Ancestors:   []primitive.ObjectID{},
+		Position:    req.Position,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if req.ParentID != "" {
+		parentID, err := primitive.ObjectIDFromHex(req.ParentID)
+		if err != nil {
+			return nil, errors.New("invalid parent category ID")
+		}
+		parent, err := uc.categoryRepo.GetByID(parentID)
+		if err != nil {
+			return nil, errors.New("parent category not found")
+		}
+		category.ParentID = &parent.ID
+		category.Ancestors = append(parent.Ancestors, parent.ID)
+	}
+
+	if err := uc.categoryRepo.Create(category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// renames, reorders or moves a category; moving it takes its whole subtree along
+func (uc *categoryUseCase) UpdateCategory(id primitive.ObjectID, req *domain.UpdateCategoryRequest) (*domain.Category, error) {
+	category, err := uc.categoryRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		if category.Slug, err = categorySlug(*req.Name); err != nil {
+			return nil, err
+		}
+		category.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		category.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.Position != nil {
+		category.Position = *req.Position
+	}
+
+	moved := false
+	if req.ParentID != nil {
+		if moved, err = uc.reparent(category, *req.ParentID); err != nil {
+			return nil, err
+		}
+	}
+	category.UpdatedAt = time.Now()
+
+	if !moved {
+		if err := uc.categoryRepo.Update(category); err != nil {
+			return nil, err
+		}
+		return category, nil
+	}
+
+	descendants, err := uc.categoryRepo.ListDescendants(category.ID)
+	if err != nil {
+		return nil, err
+	}
+	err = uc.tx.WithTransaction(func(ctx context.Context) error {
+		categoryRepo := uc.categoryRepo.WithContext(ctx)
+		if err := categoryRepo.Update(category); err != nil {
+			return err
+		}
+		// a descendant keeps the part of its path below the moved category
+		for _, descendant := range descendants {
+			ancestors := append([]primitive.ObjectID{}, category.Ancestors...)
+			ancestors = append(ancestors, descendant.Ancestors[indexOfID(descendant.Ancestors, category.ID):]...)
+			if err := categoryRepo.SetAncestors(descendant.ID, ancestors); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// deletes a category without subcategories; its blogs are left uncategorized
+func (uc *categoryUseCase) DeleteCategory(id primitive.ObjectID) error {
+	if _, err := uc.categoryRepo.GetByID(id); err != nil {
+		return err
+	}
+	hasChildren, err := uc.categoryRepo.HasChildren(id)
+	if err != nil {
+		return err
+	}
+	if hasChildren {
+		return errors.New("invalid request: category has subcategories, move or delete them first")
+	}
+
+	return uc.tx.WithTransaction(func(ctx context.Context) error {
+		ids, err := uc.blogRepo.WithContext(ctx).ClearCategory(id)
+		if err != nil {
+			return err
+		}
+		if err := uc.outbox.WithContext(ctx).Append(blogsChangedEvents(ids)...); err != nil {
+			return err
+		}
+		return uc.categoryRepo.WithContext(ctx).Delete(id)
+	})
+}
+
+func (uc *categoryUseCase) Breadcrumbs(id primitive.ObjectID) ([]domain.CategoryCrumb, error) {
+	category, err := uc.categoryRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.crumbs(category)
+}
+
+func (uc *categoryUseCase) crumbs(category *domain.Category) ([]domain.CategoryCrumb, error) {
+	crumbs := make([]domain.CategoryCrumb, 0, len(category.Ancestors)+1)
+	if len(category.Ancestors) > 0 {
+		ancestors, err := uc.categoryRepo.GetByIDs(category.Ancestors)
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[primitive.ObjectID]*domain.Category, len(ancestors))
+		for _, ancestor := range ancestors {
+			byID[ancestor.ID] = ancestor
+		}
+		for _, ancestorID := range category.Ancestors {
+			if ancestor, ok := byID[ancestorID]; ok {
+				crumbs = append(crumbs, domain.CategoryCrumb{ID: ancestor.ID, Name: ancestor.Name, Slug: ancestor.Slug})
+			}
+		}
+	}
+	return append(crumbs, domain.CategoryCrumb{ID: category.ID, Name: category.Name, Slug: category.Slug}), nil
+}
+
+// points category at a new parent, or at none for an empty parentID; reports whether it moved
+func (uc *categoryUseCase) reparent(category *domain.Category, parentID string) (bool, error) {
+	if parentID == "" {
+		if category.ParentID == nil {
+			return false, nil
+		}
+		category.ParentID = nil
+		category.Ancestors = []primitive.ObjectID{}
+		return true, nil
+	}
+
+	newParentID, err := primitive.ObjectIDFromHex(parentID)
+	if err != nil {
+		return false, errors.New("invalid parent category ID")
+	}
+	if category.ParentID != nil && *category.ParentID == newParentID {
+		return false, nil
+	}
+	parent, err := uc.categoryRepo.GetByID(newParentID)
+	if err != nil {
+		return false, errors.New("parent category not found")
+	}
+	if parent.ID == category.ID || indexOfID(parent.Ancestors, category.ID) >= 0 {
+		return false, errors.New("invalid request: a category cannot be moved under itself or one of its subcategories")
+	}
+	category.ParentID = &parent.ID
+	category.Ancestors = append(append([]primitive.ObjectID{}, parent.Ancestors...), parent.ID)
+	return true, nil
+}
+
+func (uc *categoryUseCase) lookup(idOrSlug string) (*domain.Category, error) {
+	if id, err := primitive.ObjectIDFromHex(idOrSlug); err == nil {
+		return uc.categoryRepo.GetByID(id)
+	}
+	return uc.categoryRepo.GetBySlug(idOrSlug)
+}
+
+func categorySlug(name string) (string, error) {
+	s := slug.Make(name)
+	if s == "" {
+		return "", errors.New("invalid category name: it needs at least one letter or digit")
+	}
+	return s, nil
+}
+
+// nests categories under their parents, starting from the children of parentID
+// (the top level for nil); categories keep the sibling order they came in
+func buildCategoryTree(categories []*domain.Category, parentID *primitive.ObjectID) []*domain.Category {
+	children := make(map[primitive.ObjectID][]*domain.Category)
+	var roots []*domain.Category
+	for _, category := range categories {
+		switch {
+		case category.ParentID == nil && parentID == nil,
+			category.ParentID != nil && parentID != nil && *category.ParentID == *parentID:
+			roots = append(roots, category)
+		case category.ParentID != nil:
+			children[*category.ParentID] = append(children[*category.ParentID], category)
+		}
+	}
+
+	var attach func(nodes []*domain.Category)
+	attach = func(nodes []*domain.Category) {
+		for _, node := range nodes {
+			node.Children = children[node.ID]
+			attach(node.Children)
+		}
+	}
+	attach(roots)
+	if roots == nil {
+		roots = []*domain.Category{}
+	}
+	return roots
+}
+
+func indexOfID(ids []primitive.ObjectID, id primitive.ObjectID) int {
+	for i, candidate := range ids {
+		if candidate == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"Blog-API/internal/domain"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// invites a user to co-author a blog; only the owner may invite
+func (uc *blogUseCase) InviteCollaborator(blogID primitive.ObjectID, username string, userID primitive.ObjectID) (*domain.Collaborator, error) {
+	blog, err := uc.blogRepo.GetByID(blogID)
+	if err != nil {
+		return nil, errors.New("blog not found")
+	}
+	if blog.AuthorID != userID {
+		return nil, errors.New("forbidden: only the owner can invite collaborators")
+	}
+
+	invitee, err := uc.userRepo.GetByUsername(username)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	if invitee.ID == blog.AuthorID {
+		return nil, errors.New("invalid invitation: the owner cannot be a collaborator")
+	}
+
+	collaborator := &domain.Collaborator{
+		UserID:    invitee.ID,
+		Username:  invitee.Username,
+		Status:    domain.CollaboratorPending,
+		InvitedAt: time.Now(),
+	}
+	if err := uc.blogRepo.AddCollaborator(blogID, collaborator); err != nil {
+		return nil, err
+	}
+	return collaborator, nil
+}
+
+func (uc *blogUseCase) AcceptCollaboration(blogID, userID primitive.ObjectID) error {
+	return uc.blogRepo.AcceptCollaborator(blogID, userID)
+}
+
+// lets an invitee turn down a pending invitation
+func (uc *blogUseCase) DeclineCollaboration(blogID, userID primitive.ObjectID) error {
+	blog, err := uc.blogRepo.GetByID(blogID)
+	if err != nil {
+		return errors.New("blog not found")
+	}
+	for _, c := range blog.Collaborators {
+		if c.UserID == userID && c.Status == domain.CollaboratorPending {
+			return uc.blogRepo.RemoveCollaborator(blogID, userID)
+		}
+	}
+	return errors.New("invitation not found")
+}
+
+// removes a collaborator; only the owner (or an admin) may do this
+func (uc *blogUseCase) RemoveCollaborator(blogID, collaboratorID, userID primitive.ObjectID, userRole string) error {
+	blog, err := uc.blogRepo.GetByID(blogID)
+	if err != nil {
+		return errors.New("blog not found")
+	}
+	if blog.AuthorID != userID && userRole != domain.RoleAdmin {
+		return errors.New("forbidden: only the owner can remove collaborators")
+	}
+	return uc.blogRepo.RemoveCollaborator(blogID, collaboratorID)
+}
+
+// blogs never show their collaborators publicly, as the list includes open invitations
+func (uc *blogUseCase) GetCollaborators(blogID, userID primitive.ObjectID, userRole string) ([]domain.Collaborator, error) {
+	blog, err := uc.blogRepo.GetByID(blogID)
+	if err != nil {
+		return nil, errors.New("blog not found")
+	}
+	allowed := blog.AuthorID == userID || userRole == domain.RoleAdmin
+	for _, c := range blog.Collaborators {
+		if c.UserID == userID {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return nil, errors.New("forbidden: only the owner and collaborators can see the collaborators")
+	}
+	collaborators := blog.Collaborators
+	if collaborators == nil {
+		collaborators = []domain.Collaborator{}
+	}
+	return collaborators, nil
+}
+
+func (uc *blogUseCase) GetPendingInvitations(userID primitive.ObjectID) ([]*domain.Blog, error) {
+	return uc.blogRepo.GetByCollaborator(userID, domain.CollaboratorPending)
+}
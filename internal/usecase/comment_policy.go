@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"Blog-API/internal/domain"
+	"errors"
+)
+
+type commentPolicy struct {
+	settingsRepo domain.SettingsRepository
+	commentRepo  domain.CommentRepository
+	defaultMode  string
+	classifier   domain.SpamClassifier // nil disables spam filtering
+	threshold    float64
+}
+
+func NewCommentPolicy(settingsRepo domain.SettingsRepository, commentRepo domain.CommentRepository, defaultMode string,
+	classifier domain.SpamClassifier, spamThreshold float64) domain.CommentPolicy {
+	if !domain.IsValidCommentMode(defaultMode) {
+		defaultMode = domain.CommentModeOpen
+	}
+	return &commentPolicy{
+		settingsRepo: settingsRepo,
+		commentRepo:  commentRepo,
+		defaultMode:  defaultMode,
+		classifier:   classifier,
+		threshold:    spamThreshold,
+	}
+}
+
+// decides whether a new comment is published straight away or held for moderation
+func (p *commentPolicy) Evaluate(blog *domain.Blog, comment *domain.Comment, author *domain.User) (string, error) {
+	// post owners and moderators are never held back
+	if blog.AuthorID == author.ID || domain.IsModeratorRole(author.Role) {
+		return domain.CommentApproved, nil
+	}
+
+	mode := blog.CommentMode
+	if mode == "" {
+		mode = p.SiteMode()
+	}
+
+	status := domain.CommentApproved
+	switch mode {
+	case domain.CommentModeClosed:
+		return "", errors.New("forbidden: comments are closed on this post")
+	case domain.CommentModePreModerated:
+		status = domain.CommentPending
+	case domain.CommentModeFirstTime:
+		hasApproved, err := p.commentRepo.HasApproved(author.ID)
+		if err != nil {
+			return "", err
+		}
+		if !hasApproved {
+			status = domain.CommentPending
+		}
+	}
+
+	// comments that look like spam are held for a moderator whatever the mode
+	if p.classifier != nil {
+		verdict, err := p.classifier.Classify(comment.Content, author)
+		if err != nil {
+			return "", err
+		}
+		comment.SpamScore = verdict.Score
+		if verdict.Score >= p.threshold {
+			status = domain.CommentPending
+		}
+	}
+	return status, nil
+}
+
+// returns the site-wide comment mode, falling back to the configured default
+func (p *commentPolicy) SiteMode() string {
+	mode, err := p.settingsRepo.Get(domain.SettingCommentMode)
+	if err != nil || !domain.IsValidCommentMode(mode) {
+		return p.defaultMode
+	}
+	return mode
+}
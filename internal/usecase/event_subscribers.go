@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"Blog-API/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// names under which the subscribers below register with the event bus
+const (
+	webhookSubscriber = "webhooks"
+	searchSubscriber  = "search-index"
+)
+
+// turns domain events into webhook deliveries. A retried event queues no second
+// delivery, so a handler dispatching twice can safely fail halfway.
+func SubscribeWebhooks(bus domain.EventBus, webhooks domain.WebhookDispatcher) {
+	bus.Subscribe(domain.EventBlogCreated, webhookSubscriber, func(eventID primitive.ObjectID, event domain.DomainEvent) error {
+		blog := event.(*domain.BlogCreated).Blog
+		if err := webhooks.Dispatch(eventID, domain.WebhookBlogCreated, blog.AuthorID, blog); err != nil {
+			return err
+		}
+		// posts have no draft state, so creating one publishes it
+		return webhooks.Dispatch(eventID, domain.WebhookBlogPublished, blog.AuthorID, blog)
+	})
+	bus.Subscribe(domain.EventBlogUpdated, webhookSubscriber, func(eventID primitive.ObjectID, event domain.DomainEvent) error {
+		blog := event.(*domain.BlogUpdated).Blog
+		return webhooks.Dispatch(eventID, domain.WebhookBlogUpdated, blog.AuthorID, blog)
+	})
+	bus.Subscribe(domain.EventBlogDeleted, webhookSubscriber, func(eventID primitive.ObjectID, event domain.DomainEvent) error {
+		blog := event.(*domain.BlogDeleted).Blog
+		return webhooks.Dispatch(eventID, domain.WebhookBlogDeleted, blog.AuthorID, blog)
+	})
+	bus.Subscribe(domain.EventBlogRestored, webhookSubscriber, func(eventID primitive.ObjectID, event domain.DomainEvent) error {
+		blog := event.(*domain.BlogRestored).Blog
+		return webhooks.Dispatch(eventID, domain.WebhookBlogPublished, blog.AuthorID, blog)
+	})
+	bus.Subscribe(domain.EventCommentAdded, webhookSubscriber, func(eventID primitive.ObjectID, event domain.DomainEvent) error {
+		added := event.(*domain.CommentAdded)
+		if added.Comment.Status != domain.CommentApproved {
+			return nil
+		}
+		return webhooks.Dispatch(eventID, domain.WebhookCommentCreated, added.BlogAuthorID, added.Comment)
+	})
+	bus.Subscribe(domain.EventCommentModerated, webhookSubscriber, func(eventID primitive.ObjectID, event domain.DomainEvent) error {
+		moderated := event.(*domain.CommentModerated)
+		// only announce comments that just became visible
+		wasApproved := moderated.PreviousStatus == "" || moderated.PreviousStatus == domain.CommentApproved
+		if moderated.Comment.Status != domain.CommentApproved || wasApproved {
+			return nil
+		}
+		return webhooks.Dispatch(eventID, domain.WebhookCommentCreated, moderated.BlogAuthorID, moderated.Comment)
+	})
+	bus.Subscribe(domain.EventReactionChanged, webhookSubscriber, func(eventID primitive.ObjectID, event domain.DomainEvent) error {
+		changed := event.(*domain.ReactionChanged)
+		return webhooks.Dispatch(eventID, domain.WebhookReactionChanged, changed.BlogAuthorID, changed.Counts)
+	})
+}
+
+// keeps the search index in line with blog changes. The blog is read back from the
+// database rather than taken from the event, so retried or late events cannot put
+// an outdated version in the index.
+func SubscribeSearchIndex(bus domain.EventBus, search domain.SearchUseCase) {
+	bus.Subscribe(domain.EventBlogCreated, searchSubscriber, func(eventID primitive.ObjectID, event domain.DomainEvent) error {
+		return search.Sync(event.(*domain.BlogCreated).Blog.ID)
+	})
+	bus.Subscribe(domain.EventBlogUpdated, searchSubscriber, func(eventID primitive.ObjectID, event domain.DomainEvent) error {
+		return search.Sync(event.(*domain.BlogUpdated).Blog.ID)
+	})
+	bus.Subscribe(domain.EventBlogDeleted, searchSubscriber, func(eventID primitive.ObjectID, event domain.DomainEvent) error {
+		return search.Sync(event.(*domain.BlogDeleted).Blog.ID)
+	})
+	bus.Subscribe(domain.EventBlogRestored, searchSubscriber, func(eventID primitive.ObjectID, event domain.DomainEvent) error {
+		return search.Sync(event.(*domain.BlogRestored).Blog.ID)
+	})
+	bus.Subscribe(domain.EventBlogsChanged, searchSubscriber, func(eventID primitive.ObjectID, event domain.DomainEvent) error {
+		for _, id := range event.(*domain.BlogsChanged).IDs {
+			if err := search.Sync(id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
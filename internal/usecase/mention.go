@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"Blog-API/internal/domain"
+	"Blog-API/pkg/mention"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// upper bound on resolved mentions per blog or comment, so a post cannot page the whole site
+const maxMentions = 20
+
+// resolves the @mentions in rendered HTML to users, dropping unknown usernames, the
+// author themselves and anyone who has blocked the author. Mentions in code are not
+// looked at, the same as mention.LinkHTML leaves them unlinked.
+func resolveMentions(userRepo domain.UserRepository, contentHTML string, authorID primitive.ObjectID) []domain.Mention {
+	mentions := []domain.Mention{}
+	for _, username := range mention.ParseHTML(contentHTML) {
+		if len(mentions) == maxMentions {
+			break
+		}
+		user, err := userRepo.GetByUsername(username)
+		if err != nil || user.ID == authorID || user.HasBlocked(authorID) {
+			continue
+		}
+		mentions = append(mentions, domain.Mention{UserID: user.ID, Username: user.Username})
+	}
+	return mentions
+}
+
+func mentionedUsernames(mentions []domain.Mention) []string {
+	usernames := make([]string, 0, len(mentions))
+	for _, m := range mentions {
+		usernames = append(usernames, m.Username)
+	}
+	return usernames
+}
+
+func mentionedUserIDs(mentions []domain.Mention) []primitive.ObjectID {
+	userIDs := make([]primitive.ObjectID, 0, len(mentions))
+	for _, m := range mentions {
+		userIDs = append(userIDs, m.UserID)
+	}
+	return userIDs
+}
+
+// picks the mentions of users not in notified and returns them with notified extended
+// by those users. Posts and comments keep the extended set, so a mention removed in one
+// edit and added back in the next does not notify the same person again.
+func unnotifiedMentions(mentions []domain.Mention, notified []primitive.ObjectID) ([]domain.Mention, []primitive.ObjectID) {
+	seen := make(map[primitive.ObjectID]bool, len(notified))
+	all := make([]primitive.ObjectID, 0, len(notified)+len(mentions))
+	for _, userID := range notified {
+		if !seen[userID] {
+			seen[userID] = true
+			all = append(all, userID)
+		}
+	}
+
+	fresh := []domain.Mention{}
+	for _, m := range mentions {
+		if seen[m.UserID] {
+			continue
+		}
+		seen[m.UserID] = true
+		all = append(all, m.UserID)
+		fresh = append(fresh, m)
+	}
+	return fresh, all
+}
+
+// notifies the mentioned users, once each
+func notifyMentions(notifier domain.Notifier, mentions []domain.Mention,
+	actorID primitive.ObjectID, actorUsername string, blogID primitive.ObjectID, commentID *primitive.ObjectID) {
+	notified := make(map[primitive.ObjectID]bool, len(mentions))
+	for _, m := range mentions {
+		if notified[m.UserID] {
+			continue
+		}
+		notified[m.UserID] = true
+		notifier.Notify(&domain.Notification{
+			RecipientID:   m.UserID,
+			Type:          domain.NotificationMention,
+			ActorID:       actorID,
+			ActorUsername: actorUsername,
+			BlogID:        blogID,
+			CommentID:     commentID,
+		})
+	}
+}
+
+// tells the post author about a new top level comment, or the parent's author about a reply,
+// and notifies the users mentioned in it. Called once the comment is published.
+func announceComment(notifier domain.Notifier, commentRepo domain.CommentRepository, comment *domain.Comment) {
+	if comment.ParentID != nil {
+		if parent, err := commentRepo.GetByID(*comment.ParentID); err == nil {
+			notifier.Notify(&domain.Notification{
+				RecipientID:   parent.AuthorID,
+				Type:          domain.NotificationReply,
+				ActorID:       comment.AuthorID,
+				ActorUsername: comment.AuthorUsername,
+				BlogID:        comment.BlogID,
+				CommentID:     &parent.ID,
+			})
+		}
+	} else {
+		commentID := comment.ID
+		notifier.Notify(&domain.Notification{
+			RecipientID:   comment.BlogAuthorID,
+			Type:          domain.NotificationComment,
+			ActorID:       comment.AuthorID,
+			ActorUsername: comment.AuthorUsername,
+			BlogID:        comment.BlogID,
+			CommentID:     &commentID,
+		})
+	}
+
+	commentID := comment.ID
+	notifyMentions(notifier, comment.Mentions, comment.AuthorID, comment.AuthorUsername, comment.BlogID, &commentID)
+}
@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"Blog-API/internal/domain"
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type moderationUseCase struct {
+	commentRepo  domain.CommentRepository
+	blogRepo     domain.BlogRepository
+	settingsRepo domain.SettingsRepository
+	policy       domain.CommentPolicy
+	classifier   domain.SpamClassifier // nil when spam filtering is disabled
+	notifier     domain.Notifier
+	publisher    domain.EventPublisher
+	tx           domain.Transactor
+	outbox       domain.OutboxRepository
+}
+
+func NewModerationUseCase(commentRepo domain.CommentRepository, blogRepo domain.BlogRepository,
+	settingsRepo domain.SettingsRepository, policy domain.CommentPolicy, classifier domain.SpamClassifier,
+	notifier domain.Notifier, publisher domain.EventPublisher, tx domain.Transactor, outbox domain.OutboxRepository) domain.ModerationUseCase {
+	return &moderationUseCase{
+		commentRepo:  commentRepo,
+		blogRepo:     blogRepo,
+		settingsRepo: settingsRepo,
+		policy:       policy,
+		classifier:   classifier,
+		notifier:     notifier,
+		publisher:    publisher,
+		tx:           tx,
+		outbox:       outbox,
+	}
+}
+
+// moderators see every pending comment, other users only those on their own posts
+func (uc *moderationUseCase) GetQueue(userID primitive.ObjectID, userRole string, page, limit int) ([]*domain.Comment, int64, error) {
+	if domain.IsModeratorRole(userRole) {
+		return uc.commentRepo.ListPending(nil, page, limit)
+	}
+	return uc.commentRepo.ListPending(&userID, page, limit)
+}
+
+func (uc *moderationUseCase) ModerateComment(commentID primitive.ObjectID, action string, userID primitive.ObjectID, userRole string) (*domain.Comment, error) {
+	var status string
+	switch action {
+	case domain.ModerationApprove:
+		status = domain.CommentApproved
+	case domain.ModerationReject:
+		status = domain.CommentRejected
+	case domain.ModerationSpam:
+		status = domain.CommentSpam
+	default:
+		return nil, errors.New("invalid moderation action")
+	}
+
+	comment, err := uc.commentRepo.GetByID(commentID)
+	if err != nil {
+		return nil, errors.New("comment not found")
+	}
+	blog, err := uc.blogRepo.GetByID(comment.BlogID)
+	if err != nil {
+		return nil, errors.New("blog not found")
+	}
+	if blog.AuthorID != userID && !domain.IsModeratorRole(userRole) {
+		return nil, errors.New("forbidden: you are not authorized to moderate this comment")
+	}
+
+	isApproved := status == domain.CommentApproved
+	var previous, moderated *domain.Comment
+	err = uc.tx.WithTransaction(func(ctx context.Context) error {
+		commentRepo := uc.commentRepo.WithContext(ctx)
+		var err error
+		previous, err = commentRepo.SetStatus(commentID, status, userID)
+		if err != nil {
+			return err
+		}
+
+		// counters only track published comments
+		wasApproved := previous.IsApproved()
+		if wasApproved != isApproved {
+			delta := 1
+			if wasApproved {
+				delta = -1
+			}
+			if err := uc.blogRepo.WithContext(ctx).IncrementCommentCount(blog.ID, delta); err != nil {
+				return err
+			}
+			if previous.ParentID != nil {
+				if err := commentRepo.IncrementReplyCount(*previous.ParentID, delta); err != nil {
+					return err
+				}
+			}
+		}
+
+		now := time.Now()
+		copied := *previous
+		moderated = &copied
+		moderated.Status = status
+		moderated.ModeratedBy = &userID
+		moderated.ModeratedAt = &now
+		return uc.outbox.WithContext(ctx).Append(&domain.CommentModerated{
+			Comment:        moderated,
+			BlogAuthorID:   blog.AuthorID,
+			PreviousStatus: previous.Status,
+			ModeratorID:    userID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	wasApproved := previous.IsApproved()
+
+	// a held comment is announced the first time it is published
+	if isApproved && !wasApproved && previous.ModeratedAt == nil {
+		announceComment(uc.notifier, uc.commentRepo, previous)
+	}
+	if previous.Status != status {
+		uc.notifier.Notify(&domain.Notification{
+			RecipientID: previous.AuthorID,
+			Type:        domain.NotificationModeration,
+			ActorID:     userID,
+			BlogID:      previous.BlogID,
+			CommentID:   &previous.ID,
+			Status:      status,
+		})
+	}
+
+	if err := uc.trainSpamFilter(previous, action); err != nil {
+		return nil, err
+	}
+
+	// the comment just became visible to everyone watching the post
+	if isApproved && !wasApproved {
+		uc.publisher.Publish(domain.BlogTopic(moderated.BlogID), domain.EventComment, moderated)
+	}
+	return moderated, nil
+}
+
+// teaches the spam filter from a moderator decision: spam trains as spam, approval as ham.
+// Rejections say nothing about spam, and a comment whose label flips is first untrained.
+func (uc *moderationUseCase) trainSpamFilter(comment *domain.Comment, action string) error {
+	if uc.classifier == nil || comment.Content == "" {
+		return nil
+	}
+
+	var label string
+	switch action {
+	case domain.ModerationSpam:
+		label = domain.SpamLabelSpam
+	case domain.ModerationApprove:
+		label = domain.SpamLabelHam
+	default:
+		return nil
+	}
+	if comment.TrainedAs == label {
+		return nil
+	}
+
+	if comment.TrainedAs != "" {
+		if err := uc.classifier.Untrain(comment.Content, comment.TrainedAs); err != nil {
+			return err
+		}
+	}
+	if err := uc.classifier.Train(comment.Content, label); err != nil {
+		return err
+	}
+	return uc.commentRepo.SetTrainedAs(comment.ID, label)
+}
+
+func (uc *moderationUseCase) GetSiteCommentMode() (string, error) {
+	return uc.policy.SiteMode(), nil
+}
+
+func (uc *moderationUseCase) SetSiteCommentMode(mode string) error {
+	if !domain.IsValidCommentMode(mode) {
+		return errors.New("invalid comment mode")
+	}
+	return uc.settingsRepo.Set(domain.SettingCommentMode, mode)
+}
+
+// sets the mode of one post; an empty mode makes it inherit the site-wide mode again
+func (uc *moderationUseCase) SetBlogCommentMode(blogID primitive.ObjectID, mode string, userID primitive.ObjectID, userRole string) error {
+	if mode != "" && !domain.IsValidCommentMode(mode) {
+		return errors.New("invalid comment mode")
+	}
+	blog, err := uc.blogRepo.GetByID(blogID)
+	if err != nil {
+		return errors.New("blog not found")
+	}
+	if blog.AuthorID != userID && !domain.IsModeratorRole(userRole) {
+		return errors.New("forbidden: you are not authorized to change comment settings for this post")
+	}
+	return uc.blogRepo.UpdateCommentMode(blogID, mode)
+}
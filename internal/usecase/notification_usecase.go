@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"Blog-API/internal/domain"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// notification types that collapse into one notification per target while unread
+var groupedNotificationTypes = map[string]bool{
+	domain.NotificationComment: true,
+	domain.NotificationReply:   true,
+	domain.NotificationLike:    true,
+}
+
+type notificationUseCase struct {
+	notificationRepo domain.NotificationRepository
+	userRepo         domain.UserRepository
+	publisher        domain.EventPublisher
+}
+
+func NewNotificationUseCase(notificationRepo domain.NotificationRepository, userRepo domain.UserRepository,
+	publisher domain.EventPublisher) domain.NotificationUseCase {
+	return &notificationUseCase{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		publisher:        publisher,
+	}
+}
+
+// stores a notification unless it is about the recipient's own action or the recipient
+// turned its type off. Notifications are best effort and never fail the action that triggered them.
+func (uc *notificationUseCase) Notify(notification *domain.Notification) {
+	if notification.RecipientID == notification.ActorID {
+		return
+	}
+	recipient, err := uc.userRepo.GetByID(notification.RecipientID)
+	if err != nil {
+		return
+	}
+	if enabled, ok := recipient.NotificationPreferences[notification.Type]; ok && !enabled {
+		return
+	}
+
+	now := time.Now()
+	notification.ActorIDs = []primitive.ObjectID{notification.ActorID}
+	notification.CreatedAt = now
+	notification.UpdatedAt = now
+
+	if groupedNotificationTypes[notification.Type] {
+		notification.GroupKey = notificationGroupKey(notification)
+		err = uc.notificationRepo.Group(notification)
+	} else {
+		err = uc.notificationRepo.Create(notification)
+	}
+	if err != nil {
+		log.Printf("failed to deliver %s notification to %s: %v", notification.Type, notification.RecipientID.Hex(), err)
+		return
+	}
+
+	notification.ActorCount = 1
+	notification.Message = notificationMessage(notification)
+	uc.publisher.Publish(domain.UserTopic(notification.RecipientID), domain.EventNotification, notification)
+}
+
+func (uc *notificationUseCase) GetNotifications(userID primitive.ObjectID, unreadOnly bool, page, limit int) ([]*domain.Notification, int64, error) {
+	notifications, total, err := uc.notificationRepo.List(userID, unreadOnly, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, n := range notifications {
+		n.ActorCount = len(n.ActorIDs)
+		n.Message = notificationMessage(n)
+	}
+	return notifications, total, nil
+}
+
+func (uc *notificationUseCase) GetUnreadCount(userID primitive.ObjectID) (int64, error) {
+	return uc.notificationRepo.CountUnread(userID)
+}
+
+func (uc *notificationUseCase) MarkRead(id, userID primitive.ObjectID) error {
+	return uc.notificationRepo.MarkRead(id, userID)
+}
+
+func (uc *notificationUseCase) MarkAllRead(userID primitive.ObjectID) (int64, error) {
+	return uc.notificationRepo.MarkAllRead(userID)
+}
+
+// returns whether each notification type is enabled
+func (uc *notificationUseCase) GetPreferences(userID primitive.ObjectID) (map[string]bool, error) {
+	user, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	preferences := make(map[string]bool, len(domain.NotificationTypes))
+	for _, t := range domain.NotificationTypes {
+		enabled, ok := user.NotificationPreferences[t]
+		preferences[t] = !ok || enabled
+	}
+	return preferences, nil
+}
+
+// merges the given types into the user's preferences; types not mentioned keep their setting
+func (uc *notificationUseCase) UpdatePreferences(userID primitive.ObjectID, changes map[string]bool) (map[string]bool, error) {
+	for t := range changes {
+		if !domain.IsValidNotificationType(t) {
+			return nil, fmt.Errorf("invalid notification type: %s", t)
+		}
+	}
+	preferences, err := uc.GetPreferences(userID)
+	if err != nil {
+		return nil, err
+	}
+	for t, enabled := range changes {
+		preferences[t] = enabled
+	}
+	if err := uc.userRepo.UpdateNotificationPreferences(userID, preferences); err != nil {
+		return nil, err
+	}
+	return preferences, nil
+}
+
+// identifies what a grouped notification is about, e.g. likes on one post
+func notificationGroupKey(n *domain.Notification) string {
+	switch n.Type {
+	case domain.NotificationReply:
+		if n.CommentID != nil {
+			return n.Type + ":" + n.CommentID.Hex()
+		}
+	}
+	return n.Type + ":" + n.BlogID.Hex()
+}
+
+// human readable summary, e.g. "jane and 4 others liked your post"
+func notificationMessage(n *domain.Notification) string {
+	actors := n.ActorUsername
+	switch {
+	case n.ActorCount == 2:
+		actors += " and 1 other"
+	case n.ActorCount > 2:
+		actors += fmt.Sprintf(" and %d others", n.ActorCount-1)
+	}
+
+	switch n.Type {
+	case domain.NotificationComment:
+		return actors + " commented on your post"
+	case domain.NotificationReply:
+		return actors + " replied to your comment"
+	case domain.NotificationLike:
+		return actors + " liked your post"
+	case domain.NotificationMention:
+		if n.CommentID != nil {
+			return actors + " mentioned you in a comment"
+		}
+		return actors + " mentioned you in a post"
+	case domain.NotificationModeration:
+		switch n.Status {
+		case domain.CommentApproved:
+			return "Your comment was approved"
+		case domain.CommentSpam:
+			return "Your comment was marked as spam"
+		default:
+			return "Your comment was rejected"
+		}
+	}
+	return actors
+}
@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"Blog-API/internal/domain"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// runs change and appends the events it caused to the outbox in one transaction,
+// so an event is recorded if and only if the state change is
+func commitWithEvents(tx domain.Transactor, outbox domain.OutboxRepository, change func(ctx context.Context) error, events ...domain.DomainEvent) error {
+	return tx.WithTransaction(func(ctx context.Context) error {
+		if err := change(ctx); err != nil {
+			return err
+		}
+		return outbox.WithContext(ctx).Append(events...)
+	})
+}
+
+// blogs named per BlogsChanged event, so that one bulk change does not make one huge message
+const blogsPerChangeEvent = 500
+
+// the BlogsChanged events announcing a bulk change of the blogs, none when ids is empty
+func blogsChangedEvents(ids []primitive.ObjectID) []domain.DomainEvent {
+	var events []domain.DomainEvent
+	for start := 0; start < len(ids); start += blogsPerChangeEvent {
+		end := min(start+blogsPerChangeEvent, len(ids))
+		events = append(events, &domain.BlogsChanged{IDs: ids[start:end]})
+	}
+	return events
+}
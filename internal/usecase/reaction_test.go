@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"Blog-API/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// in-memory stand-ins with the per-document atomicity mongodb gives. The transactor
+// runs without a transaction, so the toggle has to be race-free on its own.
+
+type memBlogRepo struct {
+	domain.BlogRepository
+	mu   sync.Mutex
+	blog domain.Blog
+}
+
+func (r *memBlogRepo) WithContext(ctx context.Context) domain.BlogRepository { return r }
+
+func (r *memBlogRepo) GetByID(id primitive.ObjectID) (*domain.Blog, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if id != r.blog.ID {
+		return nil, fmt.Errorf("blog not found")
+	}
+	blog := r.blog
+	blog.ReactionCounts = copyCounts(r.blog.ReactionCounts)
+	return &blog, nil
+}
+
+func (r *memBlogRepo) IncrementReactionCounts(blogID primitive.ObjectID, deltas map[string]int) (map[string]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for reactionType, delta := range deltas {
+		r.blog.ReactionCounts[reactionType] += delta
+		if reactionType == domain.ReactionLike {
+			r.blog.LikeCount += delta
+		}
+	}
+	return copyCounts(r.blog.ReactionCounts), nil
+}
+
+type memUserRepo struct {
+	domain.UserRepository
+}
+
+func (r *memUserRepo) GetByID(id primitive.ObjectID) (*domain.User, error) {
+	return &domain.User{ID: id, Username: "user-" + id.Hex()}, nil
+}
+
+type memReactionRepo struct {
+	domain.ReactionRepository
+	mu        sync.Mutex
+	reactions map[primitive.ObjectID]domain.Reaction // by user
+}
+
+func (r *memReactionRepo) WithContext(ctx context.Context) domain.ReactionRepository { return r }
+
+func (r *memReactionRepo) Upsert(reaction *domain.Reaction) (*domain.Reaction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	previous, existed := r.reactions[reaction.UserID]
+	r.reactions[reaction.UserID] = *reaction
+	if !existed {
+		return nil, nil
+	}
+	return &previous, nil
+}
+
+func (r *memReactionRepo) Delete(blogID, userID primitive.ObjectID, reactionType string) (*domain.Reaction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	existing, ok := r.reactions[userID]
+	if !ok || (reactionType != "" && existing.ReactionType != reactionType) {
+		return nil, nil
+	}
+	delete(r.reactions, userID)
+	return &existing, nil
+}
+
+func (r *memReactionRepo) tally() map[string]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counts := map[string]int{}
+	for _, reaction := range r.reactions {
+		counts[reaction.ReactionType]++
+	}
+	return counts
+}
+
+type directTransactor struct{}
+
+func (directTransactor) WithTransaction(fn func(ctx context.Context) error) error {
+	return fn(context.Background())
+}
+
+type discardOutbox struct {
+	domain.OutboxRepository
+}
+
+func (o discardOutbox) WithContext(ctx context.Context) domain.OutboxRepository { return o }
+func (discardOutbox) Append(events ...domain.DomainEvent) error                 { return nil }
+
+type discardPublisher struct{}
+
+func (discardPublisher) Publish(topic, eventType string, data interface{}) {}
+
+type discardNotifier struct{}
+
+func (discardNotifier) Notify(notification *domain.Notification) {}
+
+func newReactionTestUseCase(t *testing.T) (*blogUseCase, *memBlogRepo, *memReactionRepo) {
+	t.Helper()
+	types, err := domain.ParseReactionTypes(domain.DefaultReactionTypes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blogRepo := &memBlogRepo{blog: domain.Blog{ID: primitive.NewObjectID(), AuthorID: primitive.NewObjectID(), ReactionCounts: map[string]int{}}}
+	reactionRepo := &memReactionRepo{reactions: map[primitive.ObjectID]domain.Reaction{}}
+	uc := NewBlogUseCase(BlogUseCaseDeps{
+		BlogRepo:      blogRepo,
+		UserRepo:      &memUserRepo{},
+		ReactionRepo:  reactionRepo,
+		ReactionTypes: types,
+		Notifier:      discardNotifier{},
+		Publisher:     discardPublisher{},
+		Tx:            directTransactor{},
+		Outbox:        discardOutbox{},
+	}).(*blogUseCase)
+	return uc, blogRepo, reactionRepo
+}
+
+func TestReactToBlogToggles(t *testing.T) {
+	uc, blogRepo, _ := newReactionTestUseCase(t)
+	blogID, userID := blogRepo.blog.ID, primitive.NewObjectID()
+
+	steps := []struct {
+		reaction string
+		want     string
+		likes    int
+		dislikes int
+	}{
+		{domain.ReactionLike, domain.ReactionLike, 1, 0},
+		{domain.ReactionDislike, domain.ReactionDislike, 0, 1},
+		{domain.ReactionDislike, "", 0, 0},
+		{domain.ReactionLike, domain.ReactionLike, 1, 0},
+		{domain.ReactionLike, "", 0, 0},
+	}
+	for i, step := range steps {
+		state, err := uc.ReactToBlog(blogID, userID, step.reaction)
+		if err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+		if state.MyReaction != step.want || state.Counts[domain.ReactionLike] != step.likes || state.Counts[domain.ReactionDislike] != step.dislikes {
+			t.Fatalf("step %d: got %q %v, want %q like=%d dislike=%d", i, state.MyReaction, state.Counts, step.want, step.likes, step.dislikes)
+		}
+	}
+
+	if _, err := uc.ReactToBlog(blogID, userID, "shrug"); err == nil {
+		t.Fatal("expected an unknown reaction type to be rejected")
+	}
+}
+
+func TestReactToBlogConcurrentLikes(t *testing.T) {
+	uc, blogRepo, _ := newReactionTestUseCase(t)
+	blogID := blogRepo.blog.ID
+
+	const users = 200
+	var wg sync.WaitGroup
+	for i := 0; i < users; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			state, err := uc.ReactToBlog(blogID, primitive.NewObjectID(), domain.ReactionLike)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if state.MyReaction != domain.ReactionLike {
+				t.Errorf("got reaction %q, want like", state.MyReaction)
+			}
+		}()
+	}
+	wg.Wait()
+
+	blog, _ := blogRepo.GetByID(blogID)
+	if blog.ReactionCounts[domain.ReactionLike] != users || blog.LikeCount != users {
+		t.Fatalf("got %d likes (like_count %d), want %d", blog.ReactionCounts[domain.ReactionLike], blog.LikeCount, users)
+	}
+}
+
+// a few users toggle between reaction types from many goroutines at once; the counters
+// must always match the reactions that are actually stored
+func TestReactToBlogConcurrentToggles(t *testing.T) {
+	uc, blogRepo, reactionRepo := newReactionTestUseCase(t)
+	blogID := blogRepo.blog.ID
+
+	userIDs := make([]primitive.ObjectID, 8)
+	for i := range userIDs {
+		userIDs[i] = primitive.NewObjectID()
+	}
+	reactions := []string{domain.ReactionLike, domain.ReactionDislike, "clap", domain.ReactionLike}
+
+	const goroutines, iterations = 32, 50
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				userID := userIDs[(g+i)%len(userIDs)]
+				if _, err := uc.ReactToBlog(blogID, userID, reactions[(g*i)%len(reactions)]); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	blog, _ := blogRepo.GetByID(blogID)
+	stored := reactionRepo.tally()
+	for _, reactionType := range []string{domain.ReactionLike, domain.ReactionDislike, "clap"} {
+		if blog.ReactionCounts[reactionType] != stored[reactionType] {
+			t.Errorf("%s: counter is %d but %d reactions are stored", reactionType, blog.ReactionCounts[reactionType], stored[reactionType])
+		}
+	}
+	if blog.LikeCount != stored[domain.ReactionLike] {
+		t.Errorf("like_count is %d but %d likes are stored", blog.LikeCount, stored[domain.ReactionLike])
+	}
+}
+
+func copyCounts(counts map[string]int) map[string]int {
+	copied := make(map[string]int, len(counts))
+	for k, v := range counts {
+		copied[k] = v
+	}
+	return copied
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"Blog-API/internal/domain"
+	"errors"
+	"html"
+	"strings"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// blogs read from the database per round trip while reindexing
+const reindexBatchSize = 200
+
+type searchUseCase struct {
+	index    domain.SearchIndex
+	blogRepo domain.BlogRepository
+	tags     domain.TagCanonicalizer
+}
+
+func NewSearchUseCase(index domain.SearchIndex, blogRepo domain.BlogRepository, tags domain.TagCanonicalizer) domain.SearchUseCase {
+	return &searchUseCase{
+		index:    index,
+		blogRepo: blogRepo,
+		tags:     tags,
+	}
+}
+
+func (uc *searchUseCase) Search(query *domain.SearchQuery) ([]*domain.Blog, int64, *domain.SearchFacets, error) {
+	query.Query = strings.TrimSpace(query.Query)
+	if utf8.RuneCountInString(query.Query) > domain.MaxSearchQueryLength {
+		return nil, 0, nil, errors.New("invalid search query: too long")
+	}
+	if len(query.Tags) > domain.MaxListTags {
+		return nil, 0, nil, errors.New("invalid tags: too many tags")
+	}
+	if len(query.Tags) > 0 {
+		// blogs saved before a synonym was added still carry it
+		tags, err := uc.tags.Expand(query.Tags)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		query.Tags = tags
+	}
+
+	results, err := uc.index.Search(query)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(results.Hits))
+	for _, hit := range results.Hits {
+		ids = append(ids, hit.ID)
+	}
+	found, err := uc.blogRepo.GetByIDs(ids)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	byID := make(map[primitive.ObjectID]*domain.Blog, len(found))
+	for _, blog := range found {
+		byID[blog.ID] = blog
+	}
+
+	// keep the ranking of the index; hits the database no longer has are stale and skipped
+	blogs := make([]*domain.Blog, 0, len(results.Hits))
+	for _, hit := range results.Hits {
+		blog, ok := byID[hit.ID]
+		if !ok {
+			continue
+		}
+		match := &domain.SearchMatch{Score: hit.Score, Title: html.EscapeString(blog.Title)}
+		if titles := hit.Fragments["title"]; len(titles) > 0 {
+			match.Title = titles[0]
+		}
+		match.Snippets = hit.Fragments["content"]
+		blog.Search = match
+		blogs = append(blogs, blog)
+	}
+	return blogs, results.Total, &results.Facets, nil
+}
+
+func (uc *searchUseCase) Sync(blogID primitive.ObjectID) error {
+	blog, err := uc.blogRepo.GetByID(blogID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			// deleted or trashed
+			return uc.index.Remove(blogID)
+		}
+		return err
+	}
+	return uc.index.Index(blog)
+}
+
+func (uc *searchUseCase) Reindex() (int, error) {
+	count := 0
+	after := primitive.NilObjectID
+	for {
+		blogs, err := uc.blogRepo.ListAfterID(after, reindexBatchSize)
+		if err != nil {
+			return count, err
+		}
+		if len(blogs) == 0 {
+			return count, nil
+		}
+		if err := uc.index.Index(blogs...); err != nil {
+			return count, err
+		}
+		count += len(blogs)
+		after = blogs[len(blogs)-1].ID
+	}
+}
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"Blog-API/internal/domain"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (uc *blogUseCase) CreateSeries(series *domain.Series, blogIDs []primitive.ObjectID, ownerID primitive.ObjectID) error {
+	owner, err := uc.userRepo.GetByID(ownerID)
+	if err != nil {
+		return errors.New("series owner not found")
+	}
+	if err := uc.validateSeriesBlogs(primitive.NilObjectID, blogIDs, ownerID); err != nil {
+		return err
+	}
+
+	series.ID = primitive.NewObjectID()
+	series.OwnerID = ownerID
+	series.OwnerUsername = owner.Username
+	series.BlogIDs = blogIDs
+	series.CreatedAt = time.Now()
+	series.UpdatedAt = time.Now()
+
+	return uc.seriesRepo.Create(series)
+}
+
+// returns a series together with one page of its blogs, in series order
+func (uc *blogUseCase) GetSeries(id primitive.ObjectID, page, limit int) (*domain.Series, []*domain.Blog, int64, error) {
+	series, err := uc.seriesRepo.GetByID(id)
+	if err != nil {
+		return nil, nil, 0, errors.New("series not found")
+	}
+
+	live, err := uc.seriesBlogs(series)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	start := (page - 1) * limit
+	if start > len(live) {
+		start = len(live)
+	}
+	end := start + limit
+	if end > len(live) {
+		end = len(live)
+	}
+
+	return series, live[start:end], int64(len(live)), nil
+}
+
+// the blogs of a series in series order, leaving out the ones in the trash so that
+// pages, totals and navigation skip over them
+func (uc *blogUseCase) seriesBlogs(series *domain.Series) ([]*domain.Blog, error) {
+	found, err := uc.blogRepo.GetByIDs(series.BlogIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[primitive.ObjectID]*domain.Blog, len(found))
+	for _, b := range found {
+		byID[b.ID] = b
+	}
+	blogs := make([]*domain.Blog, 0, len(found))
+	for _, blogID := range series.BlogIDs {
+		if b, ok := byID[blogID]; ok {
+			blogs = append(blogs, b)
+		}
+	}
+	return blogs, nil
+}
+
+func (uc *blogUseCase) ListSeries(page, limit int) ([]*domain.Series, int64, error) {
+	return uc.seriesRepo.List(page, limit)
+}
+
+func (uc *blogUseCase) UpdateSeries(id primitive.ObjectID, title, description *string, userID primitive.ObjectID, userRole string) (*domain.Series, error) {
+	series, err := uc.seriesRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("series not found")
+	}
+	if series.OwnerID != userID && userRole != domain.RoleAdmin {
+		return nil, errors.New("forbidden: you are not authorized to update this series")
+	}
+
+	if title != nil {
+		series.Title = *title
+	}
+	if description != nil {
+		series.Description = *description
+	}
+	series.UpdatedAt = time.Now()
+
+	if err := uc.seriesRepo.Update(series); err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// replaces the ordered list of blogs in a series
+func (uc *blogUseCase) ReorderSeries(id primitive.ObjectID, blogIDs []primitive.ObjectID, userID primitive.ObjectID, userRole string) (*domain.Series, error) {
+	series, err := uc.seriesRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("series not found")
+	}
+	if series.OwnerID != userID && userRole != domain.RoleAdmin {
+		return nil, errors.New("forbidden: you are not authorized to update this series")
+	}
+	if err := uc.validateSeriesBlogs(series.ID, blogIDs, series.OwnerID); err != nil {
+		return nil, err
+	}
+
+	series.BlogIDs = blogIDs
+	series.UpdatedAt = time.Now()
+
+	if err := uc.seriesRepo.Update(series); err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+func (uc *blogUseCase) DeleteSeries(id primitive.ObjectID, userID primitive.ObjectID, userRole string) error {
+	series, err := uc.seriesRepo.GetByID(id)
+	if err != nil {
+		return errors.New("series not found")
+	}
+	if series.OwnerID != userID && userRole != domain.RoleAdmin {
+		return errors.New("forbidden: you are not authorized to delete this series")
+	}
+	return uc.seriesRepo.Delete(id)
+}
+
+// checks that every blog exists, is written by the series owner, appears
+// only once and is not already part of a different series
+func (uc *blogUseCase) validateSeriesBlogs(seriesID primitive.ObjectID, blogIDs []primitive.ObjectID, ownerID primitive.ObjectID) error {
+	seen := make(map[primitive.ObjectID]bool, len(blogIDs))
+	for _, blogID := range blogIDs {
+		if seen[blogID] {
+			return errors.New("invalid series: a blog can only appear once")
+		}
+		seen[blogID] = true
+	}
+
+	blogs, err := uc.blogRepo.GetByIDs(blogIDs)
+	if err != nil {
+		return err
+	}
+	if len(blogs) != len(blogIDs) {
+		return errors.New("blog not found")
+	}
+	for _, blog := range blogs {
+		if blog.AuthorID != ownerID {
+			return errors.New("forbidden: only your own blogs can be added to a series")
+		}
+		if other, err := uc.seriesRepo.GetByBlogID(blog.ID); err == nil && other.ID != seriesID {
+			return errors.New("invalid series: blog already belongs to another series")
+		}
+	}
+	return nil
+}
+
+// builds the previous/next navigation for a blog, or nil if it is not in a series
+func (uc *blogUseCase) seriesNavigation(blogID primitive.ObjectID) *domain.SeriesNavigation {
+	series, err := uc.seriesRepo.GetByBlogID(blogID)
+	if err != nil {
+		return nil
+	}
+
+	blogs, err := uc.seriesBlogs(series)
+	if err != nil {
+		return nil
+	}
+	position := -1
+	for i, b := range blogs {
+		if b.ID == blogID {
+			position = i
+			break
+		}
+	}
+	if position < 0 {
+		return nil
+	}
+
+	nav := &domain.SeriesNavigation{
+		SeriesID: series.ID,
+		Title:    series.Title,
+		Position: position + 1,
+		Total:    len(blogs),
+	}
+	if position > 0 {
+		nav.Previous = seriesNavItem(blogs[position-1])
+	}
+	if position < len(blogs)-1 {
+		nav.Next = seriesNavItem(blogs[position+1])
+	}
+	return nav
+}
+
+func seriesNavItem(blog *domain.Blog) *domain.SeriesNavItem {
+	return &domain.SeriesNavItem{ID: blog.ID, Title: blog.Title, Slug: blog.Slug}
+}
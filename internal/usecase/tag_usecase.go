@@ -0,0 +1,498 @@
+package usecase
+
+import (
+	"Blog-API/internal/domain"
+	"Blog-API/pkg/fuzzy"
+	"context"
+	"errors"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// tags compared against a query when too few of them start with it
+	maxFuzzyCandidates = 1000
+	// distinct words of a draft looked up as possible tags
+	maxSuggestionCandidates = 500
+)
+
+type tagUseCase struct {
+	tagRepo     domain.TagRepository
+	synonymRepo domain.TagSynonymRepository
+	blogRepo    domain.BlogRepository
+	tx          domain.Transactor
+	outbox      domain.OutboxRepository
+}
+
+func NewTagUseCase(tagRepo domain.TagRepository, synonymRepo domain.TagSynonymRepository, blogRepo domain.BlogRepository, tx domain.Transactor, outbox domain.OutboxRepository) domain.TagUseCase {
+	return &tagUseCase{
+		tagRepo:     tagRepo,
+		synonymRepo: synonymRepo,
+		blogRepo:    blogRepo,
+		tx:          tx,
+		outbox:      outbox,
+	}
+}
+
+func (uc *tagUseCase) GetPopularTags(page, limit int) ([]*domain.Tag, int64, error) {
+	return uc.tagRepo.ListPopular(page, limit)
+}
+
+func (uc *tagUseCase) GetTag(name string, page, limit int) (*domain.Tag, []*domain.Blog, int64, error) {
+	tag, err := uc.lookup(name)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	synonyms, err := uc.synonymRepo.ListByTags([]string{tag.Name})
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	names := []string{tag.Name}
+	for _, synonym := range synonyms {
+		tag.Synonyms = append(tag.Synonyms, synonym.Name)
+		names = append(names, synonym.Name)
+	}
+	blogs, total, err := uc.blogRepo.List(&domain.ListBlogParams{
+		Page: page, Limit: limit, Tags: names, TagMatch: domain.TagMatchAny,
+		Status: domain.BlogStatusPublished, SortBy: domain.BlogSortNewest,
+	})
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return tag, blogs, total, nil
+}
+
+func (uc *tagUseCase) CreateTag(req *domain.CreateTagRequest) (*domain.Tag, error) {
+	name, err := domain.NormalizeTagName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkNotSynonym(name); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tag := &domain.Tag{
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := uc.tagRepo.Create(tag); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+// a rename rewrites every blog using the tag; renaming onto an existing tag is a merge instead
+func (uc *tagUseCase) UpdateTag(name string, req *domain.UpdateTagRequest) (*domain.Tag, error) {
+	tag, err := uc.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	oldName := tag.Name
+	if req.Name != nil {
+		if tag.Name, err = domain.NormalizeTagName(*req.Name); err != nil {
+			return nil, err
+		}
+		if err := uc.checkNotSynonym(tag.Name); err != nil {
+			return nil, err
+		}
+	}
+	if req.Description != nil {
+		tag.Description = strings.TrimSpace(*req.Description)
+	}
+	tag.UpdatedAt = time.Now()
+
+	err = uc.tx.WithTransaction(func(ctx context.Context) error {
+		if err := uc.tagRepo.WithContext(ctx).Update(tag); err != nil {
+			if strings.Contains(err.Error(), "already exists") {
+				return errors.New("tag " + tag.Name + " already exists, merge the tags instead")
+			}
+			return err
+		}
+		if tag.Name == oldName {
+			return nil
+		}
+		if err := uc.synonymRepo.WithContext(ctx).Retarget(oldName, tag.Name); err != nil {
+			return err
+		}
+		ids, err := uc.blogRepo.WithContext(ctx).ReplaceTag(oldName, tag.Name)
+		if err != nil {
+			return err
+		}
+		return uc.outbox.WithContext(ctx).Append(blogsChangedEvents(ids)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+func (uc *tagUseCase) MergeTags(name, into string) (*domain.Tag, error) {
+	source, err := uc.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	target, err := uc.lookup(into)
+	if err != nil {
+		return nil, err
+	}
+	if source.ID == target.ID {
+		return nil, errors.New("invalid request: a tag cannot be merged into itself")
+	}
+
+	err = uc.tx.WithTransaction(func(ctx context.Context) error {
+		blogRepo := uc.blogRepo.WithContext(ctx)
+		tagRepo := uc.tagRepo.WithContext(ctx)
+		ids, err := blogRepo.ReplaceTag(source.Name, target.Name)
+		if err != nil {
+			return err
+		}
+		if err := uc.outbox.WithContext(ctx).Append(blogsChangedEvents(ids)...); err != nil {
+			return err
+		}
+		if err := tagRepo.Delete(source.ID); err != nil {
+			return err
+		}
+		// the merged name lives on as a synonym so later posts using it end up on the target
+		synonymRepo := uc.synonymRepo.WithContext(ctx)
+		if err := synonymRepo.Retarget(source.Name, target.Name); err != nil {
+			return err
+		}
+		err = synonymRepo.Create(&domain.TagSynonym{Name: source.Name, Tag: target.Name, CreatedAt: time.Now()})
+		if err != nil {
+			return err
+		}
+		// blogs that had both tags now count once, so recount instead of adding the two up
+		count, err := blogRepo.CountByTag(target.Name)
+		if err != nil {
+			return err
+		}
+		target.UsageCount = count
+		return tagRepo.SetUsage(target.Name, count)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// also registers the tags of blogs written before the registry existed. Tags that are not
+// in normalized form are left out; they are normalized when their blog is next saved.
+// Blogs still carrying a synonym count towards its tag.
+func (uc *tagUseCase) RecountUsage() error {
+	usage, err := uc.blogRepo.TagUsage()
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(usage))
+	for name := range usage {
+		if normalized, err := domain.NormalizeTagName(name); err == nil && normalized == name {
+			names = append(names, name)
+		}
+	}
+	resolved, err := uc.synonymRepo.Resolve(names)
+	if err != nil {
+		return err
+	}
+	counts := make(map[string]int64, len(names))
+	for _, name := range names {
+		if tag, ok := resolved[name]; ok {
+			counts[tag] += usage[name]
+			continue
+		}
+		counts[name] += usage[name]
+	}
+	return uc.tagRepo.ResetUsage(counts)
+}
+
+func (uc *tagUseCase) Canonicalize(names []string) ([]string, error) {
+	tags, err := domain.NormalizeTags(names)
+	if err != nil || len(tags) == 0 {
+		return tags, err
+	}
+	resolved, err := uc.synonymRepo.Resolve(tags)
+	if err != nil {
+		return nil, err
+	}
+	canonical := make([]string, 0, len(tags))
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		if name, ok := resolved[tag]; ok {
+			tag = name
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			canonical = append(canonical, tag)
+		}
+	}
+	return canonical, nil
+}
+
+func (uc *tagUseCase) Expand(names []string) ([]string, error) {
+	tags, err := uc.Canonicalize(names)
+	if err != nil || len(tags) == 0 {
+		return tags, err
+	}
+	synonyms, err := uc.synonymRepo.ListByTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	for _, synonym := range synonyms {
+		tags = append(tags, synonym.Name)
+	}
+	return tags, nil
+}
+
+// prefix matches on tags come first, then tags whose synonyms match, then tags within a
+// couple of typos of the query
+func (uc *tagUseCase) Autocomplete(query string, limit int) ([]*domain.Tag, error) {
+	prefix := domain.NormalizeTagPrefix(query)
+	if prefix == "" {
+		tags, _, err := uc.tagRepo.ListPopular(1, limit)
+		return tags, err
+	}
+
+	matches, err := uc.tagRepo.SearchPrefix(prefix, limit)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	for _, tag := range matches {
+		seen[tag.Name] = true
+	}
+
+	if len(matches) < limit {
+		synonyms, err := uc.synonymRepo.SearchPrefix(prefix, limit)
+		if err != nil {
+			return nil, err
+		}
+		names := []string{}
+		for _, synonym := range synonyms {
+			if !seen[synonym.Tag] {
+				names = append(names, synonym.Tag)
+			}
+		}
+		if len(names) > 0 {
+			tags, err := uc.tagRepo.GetByNames(names)
+			if err != nil {
+				return nil, err
+			}
+			sortByUsage(tags)
+			for _, tag := range tags {
+				if len(matches) < limit && !seen[tag.Name] {
+					seen[tag.Name] = true
+					matches = append(matches, tag)
+				}
+			}
+		}
+	}
+
+	// very short queries match too much by accident to be worth correcting
+	if len(matches) < limit && len(prefix) >= 3 {
+		candidates, _, err := uc.tagRepo.ListPopular(1, maxFuzzyCandidates)
+		if err != nil {
+			return nil, err
+		}
+		maxEdits := 1
+		if len(prefix) > 5 {
+			maxEdits = 2
+		}
+		type fuzzyMatch struct {
+			tag      *domain.Tag
+			distance int
+		}
+		fuzzyMatches := []fuzzyMatch{}
+		for _, tag := range candidates {
+			if seen[tag.Name] {
+				continue
+			}
+			if distance := fuzzy.PrefixDistance(prefix, tag.Name); distance <= maxEdits {
+				fuzzyMatches = append(fuzzyMatches, fuzzyMatch{tag, distance})
+			}
+		}
+		// candidates are already ordered by usage, so a stable sort keeps that order within a distance
+		sort.SliceStable(fuzzyMatches, func(i, j int) bool {
+			return fuzzyMatches[i].distance < fuzzyMatches[j].distance
+		})
+		for _, match := range fuzzyMatches {
+			if len(matches) == limit {
+				break
+			}
+			matches = append(matches, match.tag)
+		}
+	}
+	return matches, nil
+}
+
+var draftWordSeparators = regexp.MustCompile(`[^a-z0-9]+`)
+
+// looks the words and two word phrases of the draft up as tags or synonyms. A tag scores by
+// how often the draft mentions it, with the title counting three times, and a little by how
+// widely it is used already.
+func (uc *tagUseCase) SuggestTags(req *domain.SuggestTagsRequest, limit int) ([]*domain.Tag, error) {
+	mentions := map[string]int{}
+	countTerms(mentions, req.Title, 3)
+	countTerms(mentions, req.Content, 1)
+
+	terms := make([]string, 0, len(mentions))
+	for term := range mentions {
+		terms = append(terms, term)
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if mentions[terms[i]] != mentions[terms[j]] {
+			return mentions[terms[i]] > mentions[terms[j]]
+		}
+		return terms[i] < terms[j]
+	})
+	if len(terms) > maxSuggestionCandidates {
+		terms = terms[:maxSuggestionCandidates]
+	}
+	if len(terms) == 0 {
+		return []*domain.Tag{}, nil
+	}
+
+	resolved, err := uc.synonymRepo.Resolve(terms)
+	if err != nil {
+		return nil, err
+	}
+	weights := map[string]int{}
+	names := []string{}
+	for _, term := range terms {
+		name := term
+		if tag, ok := resolved[term]; ok {
+			name = tag
+		}
+		if _, ok := weights[name]; !ok {
+			names = append(names, name)
+		}
+		weights[name] += mentions[term]
+	}
+
+	tags, err := uc.tagRepo.GetByNames(names)
+	if err != nil {
+		return nil, err
+	}
+	score := func(tag *domain.Tag) float64 {
+		return float64(weights[tag.Name]) * (1 + math.Log1p(float64(tag.UsageCount)))
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		if si, sj := score(tags[i]), score(tags[j]); si != sj {
+			return si > sj
+		}
+		return tags[i].Name < tags[j].Name
+	})
+	if len(tags) > limit {
+		tags = tags[:limit]
+	}
+	return tags, nil
+}
+
+func (uc *tagUseCase) AddSynonym(name, synonym string) (*domain.TagSynonym, error) {
+	tag, err := uc.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	alias, err := domain.NormalizeTagName(synonym)
+	if err != nil {
+		return nil, err
+	}
+	if alias == tag.Name {
+		return nil, errors.New("invalid request: a tag cannot be its own synonym")
+	}
+	// blogs use the name already, so they have to be rewritten, which is what a merge does
+	if _, err := uc.tagRepo.GetByName(alias); err == nil {
+		return nil, errors.New("invalid request: " + alias + " is a tag of its own, merge it into " + tag.Name + " instead")
+	}
+
+	created := &domain.TagSynonym{Name: alias, Tag: tag.Name, CreatedAt: time.Now()}
+	if err := uc.synonymRepo.Create(created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (uc *tagUseCase) RemoveSynonym(name, synonym string) error {
+	tag, err := uc.lookup(name)
+	if err != nil {
+		return err
+	}
+	alias, err := domain.NormalizeTagName(synonym)
+	if err != nil {
+		return errors.New("synonym not found")
+	}
+	resolved, err := uc.synonymRepo.Resolve([]string{alias})
+	if err != nil {
+		return err
+	}
+	if resolved[alias] != tag.Name {
+		return errors.New("synonym not found")
+	}
+	return uc.synonymRepo.Delete(alias)
+}
+
+// finds a tag by its name or one of its synonyms
+func (uc *tagUseCase) lookup(name string) (*domain.Tag, error) {
+	normalized, err := domain.NormalizeTagName(name)
+	if err != nil {
+		return nil, errors.New("tag not found")
+	}
+	tag, err := uc.tagRepo.GetByName(normalized)
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		return tag, err
+	}
+	resolved, err := uc.synonymRepo.Resolve([]string{normalized})
+	if err != nil {
+		return nil, err
+	}
+	canonical, ok := resolved[normalized]
+	if !ok {
+		return nil, errors.New("tag not found")
+	}
+	return uc.tagRepo.GetByName(canonical)
+}
+
+func (uc *tagUseCase) checkNotSynonym(name string) error {
+	resolved, err := uc.synonymRepo.Resolve([]string{name})
+	if err != nil {
+		return err
+	}
+	if tag, ok := resolved[name]; ok {
+		return errors.New("tag " + name + " already exists as a synonym of " + tag)
+	}
+	return nil
+}
+
+// adds every word and every pair of adjacent words (joined by a hyphen) of text to counts
+func countTerms(counts map[string]int, text string, weight int) {
+	words := draftWordSeparators.Split(strings.ToLower(text), -1)
+	previous := ""
+	for _, word := range words {
+		if word == "" {
+			continue
+		}
+		if len(word) >= 2 && len(word) <= 20 {
+			counts[word] += weight
+		}
+		if previous != "" {
+			if phrase := previous + "-" + word; len(phrase) <= 20 {
+				counts[phrase] += weight
+			}
+		}
+		previous = word
+	}
+}
+
+func sortByUsage(tags []*domain.Tag) {
+	sort.SliceStable(tags, func(i, j int) bool {
+		if tags[i].UsageCount != tags[j].UsageCount {
+			return tags[i].UsageCount > tags[j].UsageCount
+		}
+		return tags[i].Name < tags[j].Name
+	})
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"Blog-API/internal/domain"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// blogs kept per ranking when the configured size is not positive, which $limit rejects
+const defaultTrendingSize = 100
+
+// how far back each window reaches; the all-time ranking leaves the decay out, as it
+// would otherwise only repeat the recent windows
+var trendingWindows = []struct {
+	name   string
+	period time.Duration
+}{
+	{domain.TrendingToday, 24 * time.Hour},
+	{domain.TrendingWeek, 7 * 24 * time.Hour},
+	{domain.TrendingAllTime, 0},
+}
+
+type trendingUseCase struct {
+	trendingRepo domain.TrendingRepository
+	blogRepo     domain.BlogRepository
+	weights      domain.TrendingWeights
+	size         int // blogs kept per ranking
+}
+
+func NewTrendingUseCase(trendingRepo domain.TrendingRepository, blogRepo domain.BlogRepository, weights domain.TrendingWeights, size int) domain.TrendingUseCase {
+	if size <= 0 {
+		size = defaultTrendingSize
+	}
+	return &trendingUseCase{
+		trendingRepo: trendingRepo,
+		blogRepo:     blogRepo,
+		weights:      weights,
+		size:         size,
+	}
+}
+
+func (uc *trendingUseCase) Refresh() error {
+	now := time.Now()
+	for _, window := range trendingWindows {
+		var since *time.Time
+		weights := uc.weights
+		if window.period > 0 {
+			start := now.Add(-window.period)
+			since = &start
+		} else {
+			weights.HalfLife = 0
+		}
+
+		entries, err := uc.blogRepo.TrendingScores(since, now, weights, uc.size)
+		if err != nil {
+			return err
+		}
+		ranking := &domain.TrendingRanking{Window: window.name, Blogs: entries, ComputedAt: now}
+		if err := uc.trendingRepo.Save(ranking); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (uc *trendingUseCase) Ranking(window string, limit int) (*domain.TrendingRanking, error) {
+	if !isTrendingWindow(window) {
+		return nil, fmt.Errorf("invalid window %q, use today, week or all", window)
+	}
+	ranking, err := uc.trendingRepo.Get(window)
+	if err != nil {
+		if !strings.Contains(err.Error(), "not found") {
+			return nil, err
+		}
+		// the first refresh has not finished yet
+		ranking = &domain.TrendingRanking{Window: window, Blogs: []domain.TrendingEntry{}}
+	}
+	if len(ranking.Blogs) > limit {
+		ranking.Blogs = ranking.Blogs[:limit]
+	}
+	return ranking, nil
+}
+
+func isTrendingWindow(window string) bool {
+	for _, w := range trendingWindows {
+		if w.name == window {
+			return true
+		}
+	}
+	return false
+}
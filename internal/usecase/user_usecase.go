@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"Blog-API/internal/domain"
+	"context"
 	"errors"
 	"time"
 
@@ -13,14 +14,18 @@ type UserUseCase struct {
 	passwordService domain.PasswordService
 	jwtService      domain.JWTService
 	sessionRepo     domain.SessionRepository
+	tx              domain.Transactor
+	outbox          domain.OutboxRepository
 }
 
-func NewUserUseCase(userRepo domain.UserRepository, passwordService domain.PasswordService, jwtService domain.JWTService, sessionRepo domain.SessionRepository) domain.UserUseCase {
+func NewUserUseCase(userRepo domain.UserRepository, passwordService domain.PasswordService, jwtService domain.JWTService, sessionRepo domain.SessionRepository, tx domain.Transactor, outbox domain.OutboxRepository) domain.UserUseCase {
 	return &UserUseCase{
 		userRepo:        userRepo,
 		passwordService: passwordService,
 		jwtService:      jwtService,
 		sessionRepo:     sessionRepo,
+		tx:              tx,
+		outbox:          outbox,
 	}
 }
 
@@ -53,7 +58,13 @@ func (u *UserUseCase) Register(username, email, password string) (*domain.User,
 		UpdatedAt: time.Now(),
 	}
 
-	if err := u.userRepo.Create(user); err != nil {
+	err = u.tx.WithTransaction(func(ctx context.Context) error {
+		if err := u.userRepo.WithContext(ctx).Create(user); err != nil {
+			return err
+		}
+		return u.outbox.WithContext(ctx).Append(&domain.UserRegistered{UserID: user.ID, Username: user.Username, Email: user.Email})
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -108,6 +119,40 @@ func (u *UserUseCase) GetByID(id primitive.ObjectID) (*domain.User, error) {
 	return u.userRepo.GetByID(id)
 }
 
+func (u *UserUseCase) GetPublicProfile(username string) (*domain.PublicProfile, error) {
+	user, err := u.userRepo.GetByUsername(username)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	return &domain.PublicProfile{
+		ID:             user.ID,
+		Username:       user.Username,
+		Bio:            user.Bio,
+		ProfilePicture: user.ProfilePicture,
+		CreatedAt:      user.CreatedAt,
+	}, nil
+}
+
+// blocked users can no longer mention the user
+func (u *UserUseCase) BlockUser(userID primitive.ObjectID, username string) error {
+	blocked, err := u.userRepo.GetByUsername(username)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	if blocked.ID == userID {
+		return errors.New("invalid request: you cannot block yourself")
+	}
+	return u.userRepo.BlockUser(userID, blocked.ID)
+}
+
+func (u *UserUseCase) UnblockUser(userID primitive.ObjectID, username string) error {
+	blocked, err := u.userRepo.GetByUsername(username)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	return u.userRepo.UnblockUser(userID, blocked.ID)
+}
+
 func (u *UserUseCase) UpdateProfile(id primitive.ObjectID, bio, profilePic, contactInfo *string) (*domain.User, error) {
 	// Check if user exists
 	_, err := u.userRepo.GetByID(id)
@@ -135,7 +180,7 @@ func (u *UserUseCase) UpdateProfile(id primitive.ObjectID, bio, profilePic, cont
 }
 
 func (u *UserUseCase) UpdateRole(id primitive.ObjectID, role string) error {
-	if role != "user" && role != "admin" {
+	if role != domain.RoleUser && role != domain.RoleModerator && role != domain.RoleAdmin {
 		return errors.New("invalid role")
 	}
 	return u.userRepo.UpdateRole(id, role)
@@ -0,0 +1,333 @@
+package usecase
+
+import (
+	"Blog-API/internal/domain"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// how long a claimed delivery is hidden from other workers while it is being sent
+	deliveryLease = 2 * time.Minute
+	// upper bound on the wait between two attempts
+	maxDeliveryBackoff = 12 * time.Hour
+	// deliveries sent per worker run
+	deliveryBatchSize = 50
+)
+
+// body posted to webhook endpoints
+type webhookPayload struct {
+	ID        primitive.ObjectID `json:"id"` // event id, the same for every webhook receiving the event
+	Event     string             `json:"event"`
+	CreatedAt time.Time          `json:"created_at"`
+	Data      interface{}        `json:"data"`
+}
+
+type webhookUseCase struct {
+	webhookRepo  domain.WebhookRepository
+	deliveryRepo domain.WebhookDeliveryRepository
+	sender       domain.WebhookSender
+	maxAttempts  int
+	baseBackoff  time.Duration
+	disableAfter int
+}
+
+// failed attempts are retried up to maxAttempts times, waiting baseBackoff and doubling each time.
+// A webhook is disabled after disableAfter failed attempts in a row.
+func NewWebhookUseCase(webhookRepo domain.WebhookRepository, deliveryRepo domain.WebhookDeliveryRepository,
+	sender domain.WebhookSender, maxAttempts int, baseBackoff time.Duration, disableAfter int) domain.WebhookUseCase {
+	return &webhookUseCase{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		sender:       sender,
+		maxAttempts:  maxAttempts,
+		baseBackoff:  baseBackoff,
+		disableAfter: disableAfter,
+	}
+}
+
+func (uc *webhookUseCase) CreateWebhook(ownerID primitive.ObjectID, ownerRole string, req *domain.CreateWebhookRequest) (*domain.Webhook, error) {
+	if req.Global && ownerRole != domain.RoleAdmin {
+		return nil, errors.New("forbidden: only admins can create global webhooks")
+	}
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	webhook := &domain.Webhook{
+		ID:        primitive.NewObjectID(),
+		OwnerID:   ownerID,
+		URL:       req.URL,
+		Secret:    secret,
+		Events:    uniqueStrings(req.Events),
+		Global:    req.Global,
+		Active:    true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := uc.webhookRepo.Create(webhook); err != nil {
+		return nil, err
+	}
+	// the secret is shown this once
+	return webhook, nil
+}
+
+func (uc *webhookUseCase) ListWebhooks(ownerID primitive.ObjectID) ([]*domain.Webhook, error) {
+	webhooks, err := uc.webhookRepo.ListByOwner(ownerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range webhooks {
+		w.Secret = ""
+	}
+	return webhooks, nil
+}
+
+func (uc *webhookUseCase) GetWebhook(id, userID primitive.ObjectID, userRole string) (*domain.Webhook, error) {
+	webhook, err := uc.authorize(id, userID, userRole)
+	if err != nil {
+		return nil, err
+	}
+	webhook.Secret = ""
+	return webhook, nil
+}
+
+func (uc *webhookUseCase) UpdateWebhook(id, userID primitive.ObjectID, userRole string, req *domain.UpdateWebhookRequest) (*domain.Webhook, error) {
+	webhook, err := uc.authorize(id, userID, userRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		webhook.URL = *req.URL
+	}
+	if req.Events != nil {
+		webhook.Events = uniqueStrings(*req.Events)
+	}
+	if req.Active != nil {
+		webhook.Active = *req.Active
+		if webhook.Active {
+			// re-enabling gives a disabled endpoint a fresh start
+			webhook.ConsecutiveFailures = 0
+			webhook.DisabledAt = nil
+		}
+	}
+	webhook.UpdatedAt = time.Now()
+
+	if err := uc.webhookRepo.Update(webhook); err != nil {
+		return nil, err
+	}
+	webhook.Secret = ""
+	return webhook, nil
+}
+
+func (uc *webhookUseCase) DeleteWebhook(id, userID primitive.ObjectID, userRole string) error {
+	if _, err := uc.authorize(id, userID, userRole); err != nil {
+		return err
+	}
+	if err := uc.webhookRepo.Delete(id); err != nil {
+		return err
+	}
+	return uc.deliveryRepo.DeleteByWebhook(id)
+}
+
+func (uc *webhookUseCase) ListDeliveries(webhookID, userID primitive.ObjectID, userRole string, page, limit int) ([]*domain.WebhookDelivery, int64, error) {
+	if _, err := uc.authorize(webhookID, userID, userRole); err != nil {
+		return nil, 0, err
+	}
+	return uc.deliveryRepo.ListByWebhook(webhookID, page, limit)
+}
+
+// queues a fresh copy of an earlier delivery, with the same payload
+func (uc *webhookUseCase) Redeliver(webhookID, deliveryID, userID primitive.ObjectID, userRole string) (*domain.WebhookDelivery, error) {
+	if _, err := uc.authorize(webhookID, userID, userRole); err != nil {
+		return nil, err
+	}
+	original, err := uc.deliveryRepo.GetByID(deliveryID)
+	if err != nil || original.WebhookID != webhookID {
+		return nil, errors.New("delivery not found")
+	}
+
+	now := time.Now()
+	delivery := &domain.WebhookDelivery{
+		ID:            primitive.NewObjectID(),
+		WebhookID:     webhookID,
+		Event:         original.Event,
+		Payload:       original.Payload,
+		Status:        domain.DeliveryPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := uc.deliveryRepo.Create(delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// queues a delivery of the event for every active webhook subscribed to it.
+// It runs as an outbox subscriber, so an error makes the whole event be dispatched again;
+// the payload id is derived from the domain event, which makes that retry a no-op for the
+// deliveries already queued and lets receivers dedupe.
+func (uc *webhookUseCase) Dispatch(eventID primitive.ObjectID, event string, ownerID primitive.ObjectID, data interface{}) error {
+	webhooks, err := uc.webhookRepo.FindSubscribed(event, ownerID)
+	if err != nil {
+		return fmt.Errorf("failed to find webhooks for %s: %w", event, err)
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	payloadID := webhookEventID(eventID, event)
+	payload, err := json.Marshal(webhookPayload{ID: payloadID, Event: event, CreatedAt: eventID.Timestamp(), Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to encode %s webhook payload: %w", event, err)
+	}
+
+	for _, webhook := range webhooks {
+		delivery := &domain.WebhookDelivery{
+			ID:            primitive.NewObjectID(),
+			WebhookID:     webhook.ID,
+			EventID:       payloadID,
+			Event:         event,
+			Payload:       string(payload),
+			Status:        domain.DeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		if err := uc.deliveryRepo.CreateOnce(delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// id of the webhook event a domain event turns into: the domain event's timestamp
+// followed by a hash of its id and the webhook event, as one domain event can give
+// several webhook events (blog.created and blog.published)
+func webhookEventID(eventID primitive.ObjectID, event string) primitive.ObjectID {
+	sum := sha256.Sum256(append(eventID[:], event...))
+	var id primitive.ObjectID
+	copy(id[:4], eventID[:4])
+	copy(id[4:], sum[:8])
+	return id
+}
+
+// sends the deliveries that are due and returns how many were attempted
+func (uc *webhookUseCase) ProcessDueDeliveries() (int, error) {
+	processed := 0
+	for processed < deliveryBatchSize {
+		delivery, err := uc.deliveryRepo.ClaimDue(time.Now(), deliveryLease)
+		if err != nil {
+			return processed, err
+		}
+		if delivery == nil {
+			return processed, nil
+		}
+		if err := uc.attempt(delivery); err != nil {
+			return processed, err
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// sends one delivery and records the outcome, scheduling a retry or giving up
+func (uc *webhookUseCase) attempt(delivery *domain.WebhookDelivery) error {
+	now := time.Now()
+	delivery.UpdatedAt = now
+
+	webhook, err := uc.webhookRepo.GetByID(delivery.WebhookID)
+	if err != nil || !webhook.Active {
+		delivery.Status = domain.DeliveryFailed
+		delivery.Error = "webhook is disabled or was deleted"
+		return uc.deliveryRepo.Update(delivery)
+	}
+
+	delivery.Attempts++
+	status, sendErr := uc.sender.Send(webhook.URL, webhook.Secret, delivery.Event, delivery.ID, []byte(delivery.Payload))
+	delivery.ResponseStatus = status
+
+	if sendErr == nil {
+		delivery.Status = domain.DeliverySucceeded
+		delivery.Error = ""
+		delivery.DeliveredAt = &now
+		if err := uc.webhookRepo.ResetFailures(webhook.ID); err != nil {
+			return err
+		}
+		return uc.deliveryRepo.Update(delivery)
+	}
+
+	delivery.Error = sendErr.Error()
+	if delivery.Attempts >= uc.maxAttempts {
+		delivery.Status = domain.DeliveryFailed
+	} else {
+		delivery.NextAttemptAt = now.Add(uc.backoff(delivery.Attempts))
+	}
+
+	failures, err := uc.webhookRepo.RecordFailure(webhook.ID)
+	if err != nil {
+		return err
+	}
+	if uc.disableAfter > 0 && failures >= uc.disableAfter {
+		log.Printf("disabling webhook %s after %d failed deliveries in a row", webhook.ID.Hex(), failures)
+		if err := uc.webhookRepo.Disable(webhook.ID); err != nil {
+			return err
+		}
+	}
+	return uc.deliveryRepo.Update(delivery)
+}
+
+// exponential backoff: base, 2*base, 4*base, ... capped at maxDeliveryBackoff
+func (uc *webhookUseCase) backoff(attempts int) time.Duration {
+	wait := uc.baseBackoff
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= maxDeliveryBackoff {
+			return maxDeliveryBackoff
+		}
+	}
+	return wait
+}
+
+// owners manage their own webhooks, admins any webhook
+func (uc *webhookUseCase) authorize(id, userID primitive.ObjectID, userRole string) (*domain.Webhook, error) {
+	webhook, err := uc.webhookRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("webhook not found")
+	}
+	if webhook.OwnerID != userID && userRole != domain.RoleAdmin {
+		return nil, errors.New("forbidden: you are not authorized to manage this webhook")
+	}
+	return webhook, nil
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.New("failed to generate webhook secret")
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+func uniqueStrings(values []string) []string {
+	unique := []string{}
+	for _, v := range values {
+		if !containsString(unique, v) {
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
@@ -18,14 +18,89 @@ print("Users collection created with indexes");
 // Create blogs collection with indexes
 db.createCollection("blogs");
 db.blogs.createIndex({ "author_id": 1 });
-db.blogs.createIndex({ "author_username": 1 });
-db.blogs.createIndex({ "tags": 1 });
+db.blogs.createIndex({ "slug": 1 }, { unique: true, partialFilterExpression: { "slug": { $type: "string" } } });
+db.blogs.createIndex({ "slug_history": 1 });
+db.blogs.createIndex({ "author_username": 1, "deleted_at": 1, "created_at": -1 });
+db.blogs.createIndex({ "co_authors": 1, "deleted_at": 1, "created_at": -1 });
+db.blogs.createIndex({ "collaborators.user_id": 1 });
+db.blogs.createIndex({ "tags": 1, "deleted_at": 1, "created_at": -1 });
+db.blogs.createIndex({ "category_id": 1, "created_at": -1 });
 db.blogs.createIndex({ "created_at": -1 });
 db.blogs.createIndex({ "view_count": -1 });
-db.blogs.createIndex({ "title": "text", "content": "text" });
+db.blogs.createIndex({ "deleted_at": 1, "created_at": -1 });
+db.blogs.createIndex({ "deleted_at": 1, "like_count": -1, "created_at": -1 });
+db.blogs.createIndex({ "deleted_at": 1, "view_count": -1, "created_at": -1 });
+db.blogs.createIndex({ "deleted_at": 1, "comment_count": -1, "created_at": -1 });
+db.blogs.createIndex({ "author_id": 1, "deleted_at": -1 });
+// default weights; the server recreates the index with SEARCH_TEXT_TITLE_WEIGHT and SEARCH_TEXT_TAG_WEIGHT
+db.blogs.createIndex(
+    { "title": "text", "tags": "text", "content": "text" },
+    { name: "blog_text_search", weights: { "title": 10, "tags": 5, "content": 1 } }
+);
 
 print("Blogs collection created with indexes");
 
+// Create comments collection with indexes
+db.createCollection("comments");
+db.comments.createIndex({ "blog_id": 1, "parent_id": 1, "created_at": -1 });
+db.comments.createIndex({ "blog_id": 1, "parent_id": 1, "reply_count": -1 });
+db.comments.createIndex({ "author_id": 1, "status": 1 });
+db.comments.createIndex({ "status": 1, "blog_author_id": 1, "created_at": 1 });
+
+print("Comments collection created with indexes");
+
+// Create settings collection (site-wide settings keyed by name)
+db.createCollection("settings");
+db.settings.insertOne({ _id: "comment_mode", value: "open", updated_at: new Date() });
+
+print("Settings collection created");
+
+// Create notifications collection with indexes
+db.createCollection("notifications");
+db.notifications.createIndex({ "recipient_id": 1, "updated_at": -1 });
+db.notifications.createIndex({ "recipient_id": 1, "read": 1 });
+db.notifications.createIndex(
+    { "recipient_id": 1, "group_key": 1 },
+    { unique: true, partialFilterExpression: { read: false, group_key: { $exists: true } } }
+);
+
+print("Notifications collection created with indexes");
+
+// Create webhooks and webhook_deliveries collections with indexes
+db.createCollection("webhooks");
+db.webhooks.createIndex({ "owner_id": 1 });
+db.webhooks.createIndex({ "events": 1, "active": 1 });
+
+db.createCollection("webhook_deliveries");
+db.webhook_deliveries.createIndex({ "webhook_id": 1, "created_at": -1 });
+db.webhook_deliveries.createIndex({ "status": 1, "next_attempt_at": 1 });
+db.webhook_deliveries.createIndex(
+    { "event_id": 1, "webhook_id": 1 },
+    { unique: true, partialFilterExpression: { "event_id": { $exists: true } } }
+);
+
+print("Webhook collections created with indexes");
+
+// Create outbox collection with indexes (dispatched events expire after 7 days)
+db.createCollection("outbox");
+db.outbox.createIndex({ "status": 1, "next_attempt_at": 1 });
+db.outbox.createIndex({ "dispatched_at": 1 }, { expireAfterSeconds: 604800 });
+
+print("Outbox collection created with indexes");
+
+// Create spam_tokens collection (naive Bayes token counts, keyed by token; "__totals__" holds sample counts)
+db.createCollection("spam_tokens");
+
+print("Spam tokens collection created");
+
+// Create series collection with indexes
+db.createCollection("series");
+db.series.createIndex({ "owner_id": 1 });
+db.series.createIndex({ "blog_ids": 1 });
+db.series.createIndex({ "created_at": -1 });
+
+print("Series collection created with indexes");
+
 // Create sessions collection with indexes
 db.createCollection("sessions");
 db.sessions.createIndex({ "username": 1 });
@@ -39,11 +114,26 @@ print("Sessions collection created with indexes");
 // Create reactions collection with indexes
 db.createCollection("reactions");
 db.reactions.createIndex({ "blog_id": 1, "user_id": 1 }, { unique: true });
-db.reactions.createIndex({ "blog_id": 1, "reaction_type": 1 });
+db.reactions.createIndex({ "blog_id": 1, "reaction_type": 1, "created_at": -1 });
 db.reactions.createIndex({ "user_id": 1 });
 
 print("Reactions collection created with indexes");
 
+// Create bookmarks collection with indexes
+db.createCollection("bookmarks");
+db.bookmarks.createIndex({ "user_id": 1, "list_id": 1, "blog_id": 1 }, { unique: true });
+db.bookmarks.createIndex({ "user_id": 1, "list_id": 1, "position": 1, "_id": 1 });
+db.bookmarks.createIndex({ "blog_id": 1 });
+db.bookmarks.createIndex({ "list_id": 1 });
+
+print("Bookmarks collection created with indexes");
+
+// Create reading lists collection with indexes
+db.createCollection("reading_lists");
+db.reading_lists.createIndex({ "owner_id": 1, "created_at": -1 });
+
+print("Reading lists collection created with indexes");
+
 // Create password reset tokens collection with indexes
 db.createCollection("password_reset_tokens");
 db.password_reset_tokens.createIndex({ "user_id": 1 });
@@ -55,9 +145,30 @@ print("Password reset tokens collection created with indexes");
 // Create tags collection with indexes
 db.createCollection("tags");
 db.tags.createIndex({ "name": 1 }, { unique: true });
+db.tags.createIndex({ "usage_count": -1, "name": 1 });
 
 print("Tags collection created with indexes");
 
+// Create tag synonyms collection with indexes
+db.createCollection("tag_synonyms");
+db.tag_synonyms.createIndex({ "name": 1 }, { unique: true });
+db.tag_synonyms.createIndex({ "tag": 1 });
+
+print("Tag synonyms collection created with indexes");
+
+// Create categories collection with indexes
+db.createCollection("categories");
+db.categories.createIndex({ "slug": 1 }, { unique: true });
+db.categories.createIndex({ "parent_id": 1, "position": 1 });
+db.categories.createIndex({ "ancestors": 1 });
+
+print("Categories collection created with indexes");
+
+// Create trending collection (one ranking per window, keyed by "today", "week" or "all")
+db.createCollection("trending");
+
+print("Trending collection created");
+
 // Insert sample data (optional)
 print("Inserting sample data...");
 
@@ -81,6 +192,8 @@ db.users.insertOne({
 // Sample blog
 db.blogs.insertOne({
     title: "Welcome to Blog API",
+    slug: "welcome-to-blog-api",
+    slug_history: [],
     content: "This is a sample blog post to test the API.",
     author_id: db.users.findOne({username: "admin"})._id,
     author_username: "admin",
@@ -88,23 +201,21 @@ db.blogs.insertOne({
     view_count: 0,
     like_count: 0,
     comment_count: 0,
-    likes: [],
-    dislikes: [],
-    comments: [],
+    reaction_counts: {},
     created_at: new Date(),
     updated_at: new Date()
 });
 
 // Insert sample tags
 db.tags.insertMany([
-    { name: "technology", created_at: new Date() },
-    { name: "programming", created_at: new Date() },
-    { name: "golang", created_at: new Date() },
-    { name: "web-development", created_at: new Date() },
-    { name: "database", created_at: new Date() },
-    { name: "api", created_at: new Date() },
-    { name: "tutorial", created_at: new Date() },
-    { name: "best-practices", created_at: new Date() }
+    { name: "technology", usage_count: 0, created_at: new Date(), updated_at: new Date() },
+    { name: "programming", usage_count: 0, created_at: new Date(), updated_at: new Date() },
+    { name: "golang", usage_count: 0, created_at: new Date(), updated_at: new Date() },
+    { name: "web-development", usage_count: 0, created_at: new Date(), updated_at: new Date() },
+    { name: "database", usage_count: 0, created_at: new Date(), updated_at: new Date() },
+    { name: "api", usage_count: 0, created_at: new Date(), updated_at: new Date() },
+    { name: "tutorial", usage_count: 0, created_at: new Date(), updated_at: new Date() },
+    { name: "best-practices", usage_count: 0, created_at: new Date(), updated_at: new Date() }
 ]);
 
 print("Sample data inserted");
@@ -4,17 +4,28 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server   ServerConfig
-	MongoDB  MongoDBConfig
-	JWT      JWTConfig
-	Email    EmailConfig
-	Upload   UploadConfig
+	Server     ServerConfig
+	MongoDB    MongoDBConfig
+	JWT        JWTConfig
+	Email      EmailConfig
+	Upload     UploadConfig
+	Trash      TrashConfig
+	Moderation ModerationConfig
+	Spam       SpamConfig
+	Stream     StreamConfig
+	Webhook    WebhookConfig
+	Outbox     OutboxConfig
+	Reactions  ReactionConfig
+	Tags       TagConfig
+	Search     SearchConfig
+	Trending   TrendingConfig
 }
 
 type ServerConfig struct {
@@ -45,6 +56,73 @@ type UploadConfig struct {
 	MaxFileSize int64
 }
 
+type TrashConfig struct {
+	RetentionDays int
+	PurgeInterval time.Duration
+}
+
+type ModerationConfig struct {
+	CommentMode string // site-wide default until an admin changes it
+}
+
+type StreamConfig struct {
+	HeartbeatInterval time.Duration
+	BufferSize        int // undelivered events per connection before it is dropped
+	HistorySize       int // events kept for Last-Event-ID resume
+}
+
+type WebhookConfig struct {
+	Timeout      time.Duration
+	MaxAttempts  int
+	BaseBackoff  time.Duration // wait before the first retry, doubled for each later one
+	DisableAfter int           // failed attempts in a row before a webhook is disabled
+	PollInterval time.Duration
+	AllowPrivate bool // allow endpoints on loopback and private networks (development only)
+}
+
+type OutboxConfig struct {
+	PollInterval time.Duration
+	MaxAttempts  int
+	BaseBackoff  time.Duration // wait before the first retry, doubled for each later one
+}
+
+type ReactionConfig struct {
+	Types []string // "name:emoji" pairs, empty for the built-in set
+}
+
+type TagConfig struct {
+	RecountInterval time.Duration // how often usage counts are recomputed from the blogs
+}
+
+type SearchConfig struct {
+	IndexPath    string  // directory of the embedded search index
+	Language     string  // stemming language of titles and content; changing it needs a reindex
+	TitleBoost   float64 // weight of a title match relative to a content match
+	TagBoost     float64 // weight of a tag match relative to a content match
+	MaxFuzziness int     // most typos tolerated per word, 0 to turn typo tolerance off
+
+	// weights of the MongoDB text index behind GET /blogs/search, relative to a content
+	// match; the index is rebuilt on startup when they change
+	TextTitleWeight int
+	TextTagWeight   int
+}
+
+type TrendingConfig struct {
+	ViewWeight      float64
+	LikeWeight      float64
+	DislikeWeight   float64
+	CommentWeight   float64
+	HalfLife        time.Duration // age at which a blog's score has halved
+	RefreshInterval time.Duration
+	Size            int // blogs kept per ranking
+}
+
+type SpamConfig struct {
+	Enabled        bool
+	Threshold      float64  // comments scoring at or above this are held for moderation
+	BlockedDomains []string // links to these domains (or their subdomains) mark a comment as spam
+}
+
 func Load() *Config {
 	if err := godotenv.Load(); err != nil {
 		log.Println("No .env file found, using environment variables")
@@ -74,6 +152,61 @@ func Load() *Config {
 			Path:        getEnv("UPLOAD_PATH", "./uploads"),
 			MaxFileSize: getInt64Env("MAX_FILE_SIZE", 5*1024*1024), // 5MB
 		},
+		Trash: TrashConfig{
+			RetentionDays: getPositiveIntEnv("TRASH_RETENTION_DAYS", 30),
+			PurgeInterval: getPositiveDurationEnv("TRASH_PURGE_INTERVAL", time.Hour),
+		},
+		Moderation: ModerationConfig{
+			CommentMode: getEnv("COMMENT_MODE", "open"),
+		},
+		Spam: SpamConfig{
+			Enabled:        getBoolEnv("SPAM_FILTER_ENABLED", true),
+			Threshold:      getFloatEnv("SPAM_THRESHOLD", 0.7),
+			BlockedDomains: getListEnv("SPAM_BLOCKED_DOMAINS", nil),
+		},
+		Stream: StreamConfig{
+			HeartbeatInterval: getPositiveDurationEnv("STREAM_HEARTBEAT_INTERVAL", 15*time.Second),
+			BufferSize:        getIntEnv("STREAM_BUFFER_SIZE", 64),
+			HistorySize:       getIntEnv("STREAM_HISTORY_SIZE", 1000),
+		},
+		Webhook: WebhookConfig{
+			Timeout:      getDurationEnv("WEBHOOK_TIMEOUT", 10*time.Second),
+			MaxAttempts:  getIntEnv("WEBHOOK_MAX_ATTEMPTS", 8),
+			BaseBackoff:  getDurationEnv("WEBHOOK_BASE_BACKOFF", 30*time.Second),
+			DisableAfter: getIntEnv("WEBHOOK_DISABLE_AFTER", 20),
+			PollInterval: getPositiveDurationEnv("WEBHOOK_POLL_INTERVAL", 5*time.Second),
+			AllowPrivate: getBoolEnv("WEBHOOK_ALLOW_PRIVATE", false),
+		},
+		Outbox: OutboxConfig{
+			PollInterval: getPositiveDurationEnv("OUTBOX_POLL_INTERVAL", time.Second),
+			MaxAttempts:  getIntEnv("OUTBOX_MAX_ATTEMPTS", 10),
+			BaseBackoff:  getDurationEnv("OUTBOX_BASE_BACKOFF", 10*time.Second),
+		},
+		Reactions: ReactionConfig{
+			Types: getListEnv("REACTION_TYPES", nil),
+		},
+		Tags: TagConfig{
+			RecountInterval: getPositiveDurationEnv("TAG_RECOUNT_INTERVAL", time.Hour),
+		},
+		Search: SearchConfig{
+			IndexPath:    getEnv("SEARCH_INDEX_PATH", "./data/search.bleve"),
+			Language:     getEnv("SEARCH_LANGUAGE", "en"),
+			TitleBoost:   getFloatEnv("SEARCH_TITLE_BOOST", 3),
+			TagBoost:     getFloatEnv("SEARCH_TAG_BOOST", 2),
+			MaxFuzziness: getIntEnv("SEARCH_MAX_FUZZINESS", 2),
+
+			TextTitleWeight: getPositiveIntEnv("SEARCH_TEXT_TITLE_WEIGHT", 10),
+			TextTagWeight:   getPositiveIntEnv("SEARCH_TEXT_TAG_WEIGHT", 5),
+		},
+		Trending: TrendingConfig{
+			ViewWeight:      getFloatEnv("TRENDING_VIEW_WEIGHT", 0.1),
+			LikeWeight:      getFloatEnv("TRENDING_LIKE_WEIGHT", 1),
+			DislikeWeight:   getFloatEnv("TRENDING_DISLIKE_WEIGHT", 1),
+			CommentWeight:   getFloatEnv("TRENDING_COMMENT_WEIGHT", 2),
+			HalfLife:        getDurationEnv("TRENDING_HALF_LIFE", 24*time.Hour),
+			RefreshInterval: getPositiveDurationEnv("TRENDING_REFRESH_INTERVAL", 10*time.Minute),
+			Size:            getPositiveIntEnv("TRENDING_SIZE", 100),
+		},
 	}
 }
 
@@ -94,6 +227,14 @@ func getIntEnv(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// like getIntEnv, for sizes and limits: zero or negative falls back to the default
+func getPositiveIntEnv(key string, defaultValue int) int {
+	if intValue := getIntEnv(key, defaultValue); intValue > 0 {
+		return intValue
+	}
+	return defaultValue
+}
+
 func getInt64Env(key string, defaultValue int64) int64 {
 	if value := os.Getenv(key); value != "" {
 		if intValue, err := strconv.ParseInt(value, 10, 64); err == nil {
@@ -103,6 +244,39 @@ func getInt64Env(key string, defaultValue int64) int64 {
 	return defaultValue
 }
 
+func getFloatEnv(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+func getBoolEnv(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+// comma separated list, empty entries are dropped
+func getListEnv(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
 func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
@@ -110,4 +284,12 @@ func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 		}
 	}
 	return defaultValue
-} 
\ No newline at end of file
+}
+
+// like getDurationEnv, for intervals a ticker runs at: zero or negative falls back to the default
+func getPositiveDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	if duration := getDurationEnv(key, defaultValue); duration > 0 {
+		return duration
+	}
+	return defaultValue
+}
@@ -0,0 +1,34 @@
+package fuzzy
+
+// fewest single character insertions, deletions and substitutions that turn query
+// into some prefix of s, so "pyton" is one edit away from "python-tips" and
+// "kubernets" one away from "kubernetes"
+func PrefixDistance(query, s string) int {
+	q, t := []rune(query), []rune(s)
+
+	// prev[j] is the distance between the query read so far and the first j runes of s
+	prev := make([]int, len(t)+1)
+	curr := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(q); i++ {
+		curr[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if q[i-1] == t[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	best := prev[0]
+	for _, d := range prev[1:] {
+		if d < best {
+			best = d
+		}
+	}
+	return best
+}
@@ -0,0 +1,219 @@
+package highlight
+
+import (
+	"html"
+	"strings"
+	"unicode"
+
+	nethtml "golang.org/x/net/html"
+)
+
+const (
+	openTag  = "<mark>"
+	closeTag = "</mark>"
+)
+
+// returns the lowercased words and "quoted phrases" of a full-text query, leaving out
+// -excluded ones, e.g. `"go modules" -vendor proxy` gives ["go modules", "proxy"]
+func Terms(query string) []string {
+	var terms []string
+	seen := map[string]bool{}
+	add := func(term string) {
+		term = strings.ToLower(strings.Join(strings.Fields(term), " "))
+		if term != "" && !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+
+	for query != "" {
+		query = strings.TrimLeftFunc(query, unicode.IsSpace)
+		excluded := strings.HasPrefix(query, "-")
+		if excluded {
+			query = query[1:]
+		}
+		var term string
+		if strings.HasPrefix(query, `"`) {
+			end := strings.Index(query[1:], `"`)
+			if end < 0 {
+				term, query = query[1:], ""
+			} else {
+				term, query = query[1:end+1], query[end+2:]
+			}
+		} else if end := strings.IndexFunc(query, unicode.IsSpace); end >= 0 {
+			term, query = query[:end], query[end:]
+		} else {
+			term, query = query, ""
+		}
+		if !excluded {
+			add(strings.Trim(term, `"`))
+		}
+	}
+	return terms
+}
+
+// the readable text of an HTML fragment, with whitespace collapsed
+func Text(fragment string) string {
+	var sb strings.Builder
+	tokenizer := nethtml.NewTokenizer(strings.NewReader(fragment))
+	for {
+		tt := tokenizer.Next()
+		if tt == nethtml.ErrorToken {
+			break
+		}
+		switch tt {
+		case nethtml.TextToken:
+			sb.Write(tokenizer.Text())
+		case nethtml.StartTagToken, nethtml.EndTagToken, nethtml.SelfClosingTagToken:
+			// block boundaries must not glue words together
+			sb.WriteByte(' ')
+		}
+	}
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// HTML escapes text and wraps every occurrence of the terms in <mark> tags
+func Mark(text string, terms []string) string {
+	runes := []rune(text)
+	return mark(runes, 0, len(runes), matches(runes, terms))
+}
+
+// up to limit excerpts of about size characters around the first occurrences of the
+// terms, HTML escaped and marked; none when the terms do not occur in text
+func Snippets(text string, terms []string, size, limit int) []string {
+	runes := []rune(strings.Join(strings.Fields(text), " "))
+	found := matches(runes, terms)
+
+	var snippets []string
+	covered := 0
+	for _, m := range found {
+		if len(snippets) == limit {
+			break
+		}
+		if m.start < covered {
+			continue
+		}
+		start := m.start - size/3
+		if start < covered {
+			start = covered
+		}
+		if start = wordStart(runes, start); start < covered {
+			start = covered
+		}
+		end := start + size
+		if end < m.end {
+			end = m.end
+		}
+		end = wordEnd(runes, end)
+
+		var sb strings.Builder
+		if start > 0 {
+			sb.WriteString("…")
+		}
+		sb.WriteString(strings.TrimSpace(mark(runes, start, end, found)))
+		if end < len(runes) {
+			sb.WriteString("…")
+		}
+		snippets = append(snippets, sb.String())
+		covered = end
+	}
+	return snippets
+}
+
+type match struct{ start, end int }
+
+// the non-overlapping occurrences of the terms, in order. A term only matches at the
+// start of a word and the match runs to the end of that word, so "run" finds "running"
+// much like the stemming of the search itself.
+func matches(runes []rune, terms []string) []match {
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+	needles := make([][]rune, 0, len(terms))
+	for _, term := range terms {
+		if term != "" {
+			needles = append(needles, []rune(strings.ToLower(term)))
+		}
+	}
+
+	var found []match
+	for i := 0; i < len(lower); i++ {
+		if i > 0 && isWordRune(lower[i-1]) {
+			continue
+		}
+		longest := 0
+		for _, needle := range needles {
+			if len(needle) > longest && hasPrefixAt(lower, i, needle) {
+				longest = len(needle)
+			}
+		}
+		if longest == 0 {
+			continue
+		}
+		end := i + longest
+		for end < len(lower) && isWordRune(lower[end]) {
+			end++
+		}
+		found = append(found, match{i, end})
+		i = end - 1
+	}
+	return found
+}
+
+// escapes runes[start:end] and marks the parts of it covered by found
+func mark(runes []rune, start, end int, found []match) string {
+	var sb strings.Builder
+	pos := start
+	for _, m := range found {
+		if m.end <= start || m.start >= end {
+			continue
+		}
+		from, to := max(m.start, start), min(m.end, end)
+		sb.WriteString(html.EscapeString(string(runes[pos:from])))
+		sb.WriteString(openTag)
+		sb.WriteString(html.EscapeString(string(runes[from:to])))
+		sb.WriteString(closeTag)
+		pos = to
+	}
+	sb.WriteString(html.EscapeString(string(runes[pos:end])))
+	return sb.String()
+}
+
+func hasPrefixAt(s []rune, i int, prefix []rune) bool {
+	if len(s)-i < len(prefix) {
+		return false
+	}
+	for j, r := range prefix {
+		if s[i+j] != r {
+			return false
+		}
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// moves i back to the start of the word it falls in
+func wordStart(runes []rune, i int) int {
+	if i <= 0 {
+		return 0
+	}
+	for i > 0 && isWordRune(runes[i-1]) {
+		i--
+	}
+	return i
+}
+
+// moves i forward to the end of the word it falls in
+func wordEnd(runes []rune, i int) int {
+	if i >= len(runes) {
+		return len(runes)
+	}
+	for i < len(runes) && isWordRune(runes[i]) {
+		i++
+	}
+	return i
+}
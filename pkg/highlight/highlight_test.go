@@ -0,0 +1,122 @@
+package highlight
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTerms(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{`"go modules" -vendor proxy`, []string{"go modules", "proxy"}},
+		{"  Go   GO go ", []string{"go"}},
+		{`"unterminated phrase`, []string{"unterminated phrase"}},
+		{`-"excluded phrase" kept`, []string{"kept"}},
+		{`"  spaced   out  "`, []string{"spaced out"}},
+		{`- ""`, nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := Terms(tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Terms(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		fragment string
+		want     string
+	}{
+		{"<p>Hello <em>world</em></p>", "Hello world"},
+		{"<h1>Title</h1><p>Body</p>", "Title Body"},
+		{"<p>a &amp; b &lt;c&gt;</p>", "a & b <c>"},
+		{"line<br/>break", "line break"},
+		{"  plain\n\ttext  ", "plain text"},
+	}
+	for _, tt := range tests {
+		if got := Text(tt.fragment); got != tt.want {
+			t.Errorf("Text(%q) = %q, want %q", tt.fragment, got, tt.want)
+		}
+	}
+}
+
+func TestMark(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		terms []string
+		want  string
+	}{
+		{"whole word", "Go is fun", []string{"go"}, "<mark>Go</mark> is fun"},
+		{"runs to the end of the word", "running runs", []string{"run"}, "<mark>running</mark> <mark>runs</mark>"},
+		{"only at the start of a word", "rerun", []string{"run"}, "rerun"},
+		{"phrase", "the go modules proxy", []string{"go modules"}, "the <mark>go modules</mark> proxy"},
+		{"longest term wins", "golang", []string{"go", "golang"}, "<mark>golang</mark>"},
+		{"escapes the text", "<b>go</b> & more", []string{"go"}, "&lt;b&gt;<mark>go</mark>&lt;/b&gt; &amp; more"},
+		{"unicode", "Ünïcode ÜBER", []string{"über"}, "Ünïcode <mark>ÜBER</mark>"},
+		{"no terms", "nothing", nil, "nothing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Mark(tt.text, tt.terms); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnippets(t *testing.T) {
+	long := strings.Repeat("filler ", 20) + "needle " + strings.Repeat("padding ", 20) + "needle again " + strings.Repeat("tail ", 20)
+
+	tests := []struct {
+		name  string
+		text  string
+		terms []string
+		size  int
+		limit int
+		want  []string
+	}{
+		{
+			name:  "short text in one piece",
+			text:  "find the needle here",
+			terms: []string{"needle"},
+			size:  100, limit: 3,
+			want: []string{"find the <mark>needle</mark> here"},
+		},
+		{
+			name:  "no match",
+			text:  "nothing here",
+			terms: []string{"needle"},
+			size:  100, limit: 3,
+			want: nil,
+		},
+		{
+			name:  "cut at word boundaries with ellipses",
+			text:  long,
+			terms: []string{"needle"},
+			size:  30, limit: 1,
+			want: []string{"…filler filler <mark>needle</mark> padding padding…"},
+		},
+		{
+			name:  "one snippet per separate match",
+			text:  long,
+			terms: []string{"needle"},
+			size:  30, limit: 3,
+			want: []string{
+				"…filler filler <mark>needle</mark> padding padding…",
+				"…padding padding <mark>needle</mark> again tail…",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Snippets(tt.text, tt.terms, tt.size, tt.limit); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
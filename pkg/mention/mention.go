@@ -0,0 +1,100 @@
+package mention
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// @username preceded by start of text or a character that cannot be part of an email address or URL
+var pattern = regexp.MustCompile(`(^|[^\w@/.])@([A-Za-z0-9_][A-Za-z0-9_.-]{1,48}[A-Za-z0-9_])`)
+
+// returns the distinct usernames mentioned in content, in order of first appearance
+func Parse(content string) []string {
+	return parse(content, map[string]bool{}, []string{})
+}
+
+// like Parse for HTML, looking only at the text LinkHTML would link, so mentions
+// inside links, code and pre blocks do not count
+func ParseHTML(content string) []string {
+	seen := map[string]bool{}
+	usernames := []string{}
+	eachToken(content, func(raw []byte, mentionable bool) {
+		if mentionable {
+			usernames = parse(string(raw), seen, usernames)
+		}
+	})
+	return usernames
+}
+
+func parse(content string, seen map[string]bool, usernames []string) []string {
+	for _, match := range pattern.FindAllStringSubmatch(content, -1) {
+		if username := match[2]; !seen[username] {
+			seen[username] = true
+			usernames = append(usernames, username)
+		}
+	}
+	return usernames
+}
+
+// wraps mentions of the given usernames in links to their profiles.
+// Text inside links, code and pre blocks is left alone.
+func LinkHTML(content string, usernames []string) string {
+	if len(usernames) == 0 {
+		return content
+	}
+	linkable := make(map[string]bool, len(usernames))
+	for _, u := range usernames {
+		linkable[u] = true
+	}
+
+	var out bytes.Buffer
+	eachToken(content, func(raw []byte, mentionable bool) {
+		if !mentionable {
+			out.Write(raw)
+			return
+		}
+		out.WriteString(pattern.ReplaceAllStringFunc(string(raw), func(m string) string {
+			sub := pattern.FindStringSubmatch(m)
+			if !linkable[sub[2]] {
+				return m
+			}
+			return sub[1] + `<a href="/@` + html.EscapeString(sub[2]) + `" class="mention">@` + html.EscapeString(sub[2]) + `</a>`
+		}))
+	})
+	return out.String()
+}
+
+// calls fn with every token of content as written, telling whether it is text outside
+// links, code and pre blocks
+func eachToken(content string, fn func(raw []byte, mentionable bool)) {
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+	skipDepth := 0
+	for {
+		tt := tokenizer.Next()
+		if tt == html.ErrorToken {
+			return
+		}
+		raw := tokenizer.Raw()
+		switch tt {
+		case html.StartTagToken, html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			switch atom.Lookup(name) {
+			case atom.A, atom.Code, atom.Pre:
+				if tt == html.StartTagToken {
+					skipDepth++
+				} else if skipDepth > 0 {
+					skipDepth--
+				}
+			}
+			fn(raw, false)
+		case html.TextToken:
+			fn(raw, skipDepth == 0)
+		default:
+			fn(raw, false)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package mention
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"start of text", "@alice look", []string{"alice"}},
+		{"after punctuation", "thanks (@bob), and @carol.", []string{"bob", "carol"}},
+		{"in order, once each", "@bob @alice @bob", []string{"bob", "alice"}},
+		{"dots and dashes inside", "cc @jane.doe-2", []string{"jane.doe-2"}},
+		{"trailing dot is not part of it", "ask @dave.", []string{"dave"}},
+		{"email address", "mail me at me@example.com", []string{}},
+		{"url path", "see https://example.com/@carol", []string{}},
+		{"double at", "@@erin", []string{}},
+		{"too short", "@x", []string{}},
+		{"none", "no mentions here", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Parse(tt.content); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Parse(%q) = %v, want %v", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseHTML(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"paragraph", "<p>hi @alice</p>", []string{"alice"}},
+		{"code span", "<p>run <code>@bob</code> then ask @carol</p>", []string{"carol"}},
+		{"fenced block", "<pre><code>@dave\n@erin</code></pre>", []string{}},
+		{"existing link", `<p><a href="/x">@frank</a></p>`, []string{}},
+		{"after nested code", "<pre><code>@gina</code></pre><p>@gina</p>", []string{"gina"}},
+		{"each text node on its own", "<p><em>x</em>@hank</p>", []string{"hank"}},
+		{"escaped text", "<p>&lt;@ivan&gt;</p>", []string{"ivan"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseHTML(tt.content); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ParseHTML(%q) = %v, want %v", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkHTML(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		usernames []string
+		want      string
+	}{
+		{
+			name:      "links known usernames",
+			content:   "<p>hi @alice and @bob</p>",
+			usernames: []string{"alice"},
+			want:      `<p>hi <a href="/@alice" class="mention">@alice</a> and @bob</p>`,
+		},
+		{
+			name:      "leaves code and links alone",
+			content:   `<p><code>@alice</code> <a href="/x">@alice</a></p>`,
+			usernames: []string{"alice"},
+			want:      `<p><code>@alice</code> <a href="/x">@alice</a></p>`,
+		},
+		{
+			name:      "keeps the preceding character",
+			content:   "(@alice)",
+			usernames: []string{"alice"},
+			want:      `(<a href="/@alice" class="mention">@alice</a>)`,
+		},
+		{
+			name:      "nothing to link",
+			content:   "<p>@alice</p>",
+			usernames: nil,
+			want:      "<p>@alice</p>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LinkHTML(tt.content, tt.usernames); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// the mentions found are exactly the ones LinkHTML turns into links
+func TestParseHTMLMatchesLinkHTML(t *testing.T) {
+	content := "<p>@alice <code>@bob</code></p><pre>@carol</pre><p>@dave</p>"
+	usernames := ParseHTML(content)
+	linked := LinkHTML(content, []string{"alice", "bob", "carol", "dave"})
+	if got := LinkHTML(content, usernames); got != linked {
+		t.Fatalf("got %q, want %q", got, linked)
+	}
+}
@@ -0,0 +1,58 @@
+package slug
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const MaxLength = 80
+
+// builds a lowercase, URL friendly slug from any unicode text. Latin
+// diacritics are folded to their base letter, letters and digits from
+// other scripts are kept as they are and everything else becomes a dash.
+func Make(s string) string {
+	var sb strings.Builder
+	dash := false
+	length := 0
+	var base rune
+
+scan:
+	for _, r := range norm.NFKD.String(s) {
+		if length >= MaxLength {
+			break
+		}
+		switch {
+		case unicode.Is(unicode.Mn, r) && unicode.Is(unicode.Latin, base):
+			// drop accents left over from decomposing latin letters
+			continue
+		case unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r):
+			base = r
+			if dash && length > 0 {
+				// a dash right at the limit would end the slug
+				if length+1 >= MaxLength {
+					break scan
+				}
+				sb.WriteByte('-')
+				length++
+			}
+			sb.WriteRune(unicode.ToLower(r))
+			length++
+			dash = false
+		default:
+			dash = true
+		}
+	}
+
+	return norm.NFC.String(sb.String())
+}
+
+// appends a numeric collision suffix to a slug, e.g. "my-post-2"
+func WithSuffix(s string, n int) string {
+	if n <= 1 {
+		return s
+	}
+	return s + "-" + strconv.Itoa(n)
+}
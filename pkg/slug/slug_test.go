@@ -0,0 +1,57 @@
+package slug
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Hello, World!", "hello-world"},
+		{"  Leading and trailing  ", "leading-and-trailing"},
+		{"Go 1.21 -- what's new?", "go-1-21-what-s-new"},
+		{"Crème Brûlée à la carte", "creme-brulee-a-la-carte"},
+		{"Straße", "straße"},
+		{"Привет мир", "привет-мир"},
+		{"日本語のタイトル", "日本語のタイトル"},
+		{"नमस्ते दुनिया", "नमस्ते-दुनिया"},
+		{"ﬁnal ①", "final-1"},
+		{"!!!", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Make(tt.in); got != tt.want {
+			t.Errorf("Make(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMakeCapsLength(t *testing.T) {
+	got := Make(strings.Repeat("word ", 40))
+	if n := len([]rune(got)); n > MaxLength {
+		t.Fatalf("got %d characters, want at most %d", n, MaxLength)
+	}
+	if strings.HasSuffix(got, "-") {
+		t.Fatalf("got %q, a cut slug must not end with a dash", got)
+	}
+}
+
+func TestWithSuffix(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, "my-post"},
+		{1, "my-post"},
+		{2, "my-post-2"},
+		{12, "my-post-12"},
+	}
+	for _, tt := range tests {
+		if got := WithSuffix("my-post", tt.n); got != tt.want {
+			t.Errorf("WithSuffix(%q, %d) = %q, want %q", "my-post", tt.n, got, tt.want)
+		}
+	}
+}